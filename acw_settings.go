@@ -17,7 +17,7 @@ type ACWSettings struct {
 func (settings ACWSettings) MarshalJSON() ([]byte, error) {
 	data, err := json.Marshal(struct {
 		Timeout      int64  `json:"timeoutMs"`
-		WrapupPrompt string `json:"wrapupPrompt"`
+		WrapupPrompt string `json:"wrapupPrompt,omitempty"`
 	}{
 		Timeout:      settings.Timeout.Milliseconds(),
 		WrapupPrompt: settings.WrapupPrompt,
@@ -39,3 +39,41 @@ func (settings *ACWSettings) UnmarshalJSON(payload []byte) (err error) {
 	settings.WrapupPrompt = inner.WrapupPrompt
 	return
 }
+
+// Wrap-up prompts of the ACWSettings
+const (
+	WrapupPromptMandatory              = "MANDATORY"                // agents must select a wrap-up code
+	WrapupPromptOptional               = "OPTIONAL"                 // agents may select a wrap-up code
+	WrapupPromptMandatoryTimeout       = "MANDATORY_TIMEOUT"        // agents must select a wrap-up code, they become available after the timeout
+	WrapupPromptMandatoryForcedTimeout = "MANDATORY_FORCED_TIMEOUT" // agents must select a wrap-up code before the timeout, Genesys Cloud wraps up after it
+	WrapupPromptAgentRequested         = "AGENT_REQUESTED"          // agents request After Call Work
+)
+
+// IsMandatory tells if agents must select a wrap-up code
+func (settings ACWSettings) IsMandatory() bool {
+	switch settings.WrapupPrompt {
+	case WrapupPromptMandatory, WrapupPromptMandatoryTimeout, WrapupPromptMandatoryForcedTimeout:
+		return true
+	}
+	return false
+}
+
+// Deadline tells when the After Call Work started at start times out
+//
+// The zero time is returned if the After Call Work does not time out
+func (settings ACWSettings) Deadline(start time.Time) time.Time {
+	if settings.Timeout <= 0 || start.IsZero() {
+		return time.Time{}
+	}
+	switch settings.WrapupPrompt {
+	case WrapupPromptMandatoryTimeout, WrapupPromptMandatoryForcedTimeout:
+		return start.Add(settings.Timeout)
+	}
+	return time.Time{}
+}
+
+// IsExpired tells if the After Call Work started at start has timed out at the given time
+func (settings ACWSettings) IsExpired(start, now time.Time) bool {
+	deadline := settings.Deadline(start)
+	return !deadline.IsZero() && now.After(deadline)
+}
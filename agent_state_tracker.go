@@ -0,0 +1,487 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// AgentState describes the live state of an agent as seen by an AgentStateTracker
+type AgentState struct {
+	User           *User
+	Presence       *UserPresence
+	RoutingStatus  *RoutingStatus
+	Station        *UserStations
+	ActiveQueueIDs []uuid.UUID                           // the queues the agent has joined
+	Conversations  map[uuid.UUID]*AgentConversationState // the active conversations of the agent, by conversation ID
+	UpdatedAt      time.Time
+}
+
+// AgentConversationState describes a conversation an agent is active in
+type AgentConversationState struct {
+	ID        uuid.UUID
+	MediaType string // call, callback, chat, email, message, video
+	State     ConversationState
+	QueueID   uuid.UUID
+	UpdatedAt time.Time
+}
+
+// AgentStateChange describes a change of an AgentState
+//
+// Previous is nil when the agent was not tracked yet.
+// Topic is nil when the change comes from a resync.
+type AgentStateChange struct {
+	Previous *AgentState
+	Current  AgentState
+	Topic    NotificationTopic
+}
+
+// AgentStateTrackerOptions defines the options of an AgentStateTracker
+type AgentStateTrackerOptions struct {
+	Users  []Identifiable // the users to track, if empty all the users of the organization are tracked
+	Queues []Identifiable // the queues whose joined members are loaded when resyncing
+	Logger *logger.Logger
+}
+
+// AgentStateTracker maintains a live map of agents with their presence, routing status, station, and active conversations
+//
+// The tracker is seeded by Resync and kept up to date by the UserPresenceTopic, UserActivityTopic,
+// and the user conversation topics it receives via Handle or Run.
+type AgentStateTracker struct {
+	Client      *Client
+	Users       []Identifiable
+	Queues      []Identifiable
+	agents      map[uuid.UUID]*AgentState
+	subscribers map[chan AgentStateChange]struct{}
+	mutex       sync.RWMutex
+	logger      *logger.Logger
+}
+
+// NewAgentStateTracker creates a new AgentStateTracker
+//
+// The tracker is empty until Subscribe or Resync is called.
+func NewAgentStateTracker(client *Client, options AgentStateTrackerOptions) *AgentStateTracker {
+	log := options.Logger
+	if log == nil {
+		if client != nil && client.Logger != nil {
+			log = client.Logger
+		} else {
+			log = logger.Create("gcloudcx", &logger.NilStream{})
+		}
+	}
+	return &AgentStateTracker{
+		Client:      client,
+		Users:       options.Users,
+		Queues:      options.Queues,
+		agents:      map[uuid.UUID]*AgentState{},
+		subscribers: map[chan AgentStateChange]struct{}{},
+		logger:      log.Child("agent_state_tracker", "agent_state_tracker"),
+	}
+}
+
+// Topics gets the notification topics needed to track the given users
+func (tracker *AgentStateTracker) Topics(users ...Identifiable) []NotificationTopic {
+	topics := make([]NotificationTopic, 0, len(users)*8)
+	for _, user := range users {
+		topics = append(topics,
+			UserPresenceTopic{}.With(user),
+			UserActivityTopic{}.With(user),
+			UserConversationCallTopic{}.With(user),
+			UserConversationCallbackTopic{}.With(user),
+			UserConversationChatTopic{}.With(user),
+			UserConversationEmailTopic{}.With(user),
+			UserConversationMessageTopic{}.With(user),
+			UserConversationVideoTopic{}.With(user),
+		)
+	}
+	return topics
+}
+
+// Subscribe resyncs the tracker and subscribes the given channel to the topics of the tracked users
+//
+// After the websocket reconnects, call Subscribe again with the new channel
+// so the state missed while disconnected is reloaded.
+//
+// Note: Genesys Cloud allows 1000 topics per channel, that is 125 users.
+func (tracker *AgentStateTracker) Subscribe(context context.Context, channel *NotificationChannel) (correlationID string, err error) {
+	if correlationID, err = tracker.Resync(context); err != nil {
+		return
+	}
+	users := tracker.Users
+	if len(users) == 0 {
+		tracker.mutex.RLock()
+		users = make([]Identifiable, 0, len(tracker.agents))
+		for _, agent := range tracker.agents {
+			users = append(users, agent.User)
+		}
+		tracker.mutex.RUnlock()
+	}
+	_, correlationID, err = channel.Subscribe(context, tracker.Topics(users...)...)
+	return
+}
+
+// Resync reloads the state of the tracked agents from Genesys Cloud
+//
+// The tracked conversations of the agents are fetched again, the conversations the agents
+// are not active in anymore (e.g. they ended while notifications were not received) are removed.
+// Conversations that started in the meantime are tracked again with their next notification.
+//
+// Subscribers are notified of the agents whose state changed.
+func (tracker *AgentStateTracker) Resync(context context.Context) (correlationID string, err error) {
+	if tracker.Client == nil {
+		return "", errors.ArgumentMissing.With("Client")
+	}
+	log := tracker.logger.Scope("resync")
+	query := Query{"expand": "presence,routingStatus,station"}
+	var users []*User
+
+	if len(tracker.Users) == 0 {
+		if users, correlationID, err = FetchAll[User](context, tracker.Client, query); err != nil {
+			return
+		}
+	} else {
+		users = make([]*User, 0, len(tracker.Users))
+		for _, identifiable := range tracker.Users {
+			user, correlationID, err := Fetch[User](context, tracker.Client, identifiable.GetID(), query)
+			if err != nil {
+				return correlationID, err
+			}
+			users = append(users, user)
+		}
+	}
+
+	joined := map[uuid.UUID][]uuid.UUID{}
+	for _, queue := range tracker.Queues {
+		var members [][]byte
+		uri := NewURI("/routing/queues/%s/members", queue.GetID()).WithQuery(Query{"joined": true, "pageSize": 100})
+		if members, correlationID, err = tracker.Client.FetchEntities(context, uri); err != nil {
+			return
+		}
+		for _, payload := range members {
+			var member struct {
+				ID uuid.UUID `json:"id"`
+			}
+			if err := json.Unmarshal(payload, &member); err == nil && member.ID != uuid.Nil {
+				joined[member.ID] = append(joined[member.ID], queue.GetID())
+			}
+		}
+	}
+
+	conversations, correlationID, err := tracker.fetchConversations(context)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	changes := make([]AgentStateChange, 0, len(users))
+	tracker.mutex.Lock()
+	for _, user := range users {
+		agent, found := tracker.agents[user.ID]
+		var previous *AgentState
+		if found {
+			previous = agent.clone()
+		} else {
+			agent = &AgentState{Conversations: map[uuid.UUID]*AgentConversationState{}}
+			tracker.agents[user.ID] = agent
+		}
+		agent.User = user
+		agent.Presence = user.Presence
+		agent.RoutingStatus = user.RoutingStatus
+		agent.Station = user.Station
+		agent.Conversations = resyncConversations(user.ID, agent.Conversations, conversations)
+		if len(tracker.Queues) > 0 {
+			agent.ActiveQueueIDs = joined[user.ID]
+		}
+		agent.UpdatedAt = now
+		changes = append(changes, AgentStateChange{Previous: previous, Current: *agent.clone()})
+	}
+	tracker.mutex.Unlock()
+	log.Infof("Resynced %d agents", len(users))
+	tracker.notify(changes...)
+	return correlationID, nil
+}
+
+// fetchConversations fetches the conversations of the tracked agents
+//
+// The conversations that are not found are nil
+func (tracker *AgentStateTracker) fetchConversations(context context.Context) (conversations map[uuid.UUID]*Conversation, correlationID string, err error) {
+	tracker.mutex.RLock()
+	conversations = map[uuid.UUID]*Conversation{}
+	for _, agent := range tracker.agents {
+		for id := range agent.Conversations {
+			conversations[id] = nil
+		}
+	}
+	tracker.mutex.RUnlock()
+
+	for id := range conversations {
+		conversation, correlationID, err := Fetch[Conversation](context, tracker.Client, id)
+		if isNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, correlationID, err
+		}
+		conversations[id] = conversation
+	}
+	return conversations, correlationID, nil
+}
+
+// resyncConversations gets the tracked conversations the user is still active in
+func resyncConversations(userID uuid.UUID, tracked map[uuid.UUID]*AgentConversationState, conversations map[uuid.UUID]*Conversation) map[uuid.UUID]*AgentConversationState {
+	active := map[uuid.UUID]*AgentConversationState{}
+	for id, state := range tracked {
+		conversation := conversations[id]
+		if conversation == nil || !conversation.EndTime.IsZero() {
+			continue
+		}
+		for _, participant := range conversation.Participants {
+			if participant.User != nil && participant.User.ID == userID && participant.EndTime.IsZero() && !participant.State.IsTerminal() {
+				resynced := *state
+				if len(participant.State) > 0 {
+					resynced.State = participant.State
+				}
+				resynced.UpdatedAt = time.Now().UTC()
+				active[id] = &resynced
+				break
+			}
+		}
+	}
+	return active
+}
+
+// Run handles the topics received on the given channel until the channel is closed or the context is done
+//
+// Run consumes all the topics of the channel, topics the tracker does not need are discarded.
+// Applications that need these topics should call Handle from their own loop instead.
+func (tracker *AgentStateTracker) Run(context context.Context, channel *NotificationChannel) error {
+	for {
+		select {
+		case <-context.Done():
+			return context.Err()
+		case topic, ok := <-channel.TopicReceived:
+			if !ok {
+				return nil
+			}
+			tracker.Handle(topic)
+		}
+	}
+}
+
+// Handle updates the tracker with the given topic
+//
+// Returns true if the topic was used by the tracker
+func (tracker *AgentStateTracker) Handle(topic NotificationTopic) bool {
+	var userID uuid.UUID
+	var update func(agent *AgentState)
+
+	switch topic := topic.(type) {
+	case UserPresenceTopic:
+		if topic.User == nil {
+			return false
+		}
+		userID = topic.User.ID
+		update = func(agent *AgentState) {
+			presence := topic.Presence
+			agent.Presence = &presence
+		}
+	case UserActivityTopic:
+		if topic.User == nil {
+			return false
+		}
+		userID = topic.User.ID
+		update = func(agent *AgentState) {
+			if topic.Presence != nil {
+				agent.Presence = topic.Presence
+			}
+			if topic.RoutingStatus != nil {
+				agent.RoutingStatus = topic.RoutingStatus
+			}
+			agent.ActiveQueueIDs = make([]uuid.UUID, 0, len(topic.ActiveQueues))
+			for _, queue := range topic.ActiveQueues {
+				agent.ActiveQueueIDs = append(agent.ActiveQueueIDs, queue.ID)
+			}
+		}
+	case UserConversationCallTopic:
+		return tracker.handleConversation(topic, topic.User, topic.ConversationID, "call", topic.Participants)
+	case UserConversationCallbackTopic:
+		return tracker.handleConversation(topic, topic.User, topic.ConversationID, "callback", topic.Participants)
+	case UserConversationChatTopic:
+		return tracker.handleConversation(topic, topic.User, topic.ConversationID, "chat", topic.Participants)
+	case UserConversationEmailTopic:
+		return tracker.handleConversation(topic, topic.User, topic.ConversationID, "email", topic.Participants)
+	case UserConversationMessageTopic:
+		return tracker.handleConversation(topic, topic.User, topic.ConversationID, "message", topic.Participants)
+	case UserConversationVideoTopic:
+		return tracker.handleConversation(topic, topic.User, topic.ConversationID, "video", topic.Participants)
+	default:
+		return false
+	}
+	return tracker.update(topic, userID, update)
+}
+
+// handleConversation updates the conversations of an agent from a user conversation topic
+func (tracker *AgentStateTracker) handleConversation(topic NotificationTopic, user *User, conversationID uuid.UUID, mediaType string, participants []*Participant) bool {
+	if user == nil || conversationID == uuid.Nil {
+		return false
+	}
+	var participant *Participant
+	for _, candidate := range participants {
+		if candidate.User != nil && candidate.User.ID == user.ID && candidate.EndTime.IsZero() {
+			participant = candidate
+		}
+	}
+	return tracker.update(topic, user.ID, func(agent *AgentState) {
+		if participant == nil || participant.State.IsTerminal() {
+			delete(agent.Conversations, conversationID)
+			return
+		}
+		conversation := &AgentConversationState{
+			ID:        conversationID,
+			MediaType: mediaType,
+			State:     participant.State,
+			UpdatedAt: time.Now().UTC(),
+		}
+		if participant.Queue != nil {
+			conversation.QueueID = participant.Queue.ID
+		}
+		agent.Conversations[conversationID] = conversation
+	})
+}
+
+// update applies an update to an agent and notifies the subscribers
+func (tracker *AgentStateTracker) update(topic NotificationTopic, userID uuid.UUID, update func(agent *AgentState)) bool {
+	if userID == uuid.Nil {
+		return false
+	}
+	tracker.mutex.Lock()
+	agent, found := tracker.agents[userID]
+	var previous *AgentState
+	if found {
+		previous = agent.clone()
+	} else {
+		if len(tracker.Users) > 0 && !slices.ContainsFunc(tracker.Users, func(user Identifiable) bool { return user.GetID() == userID }) {
+			tracker.mutex.Unlock()
+			return false
+		}
+		agent = &AgentState{User: &User{ID: userID}, Conversations: map[uuid.UUID]*AgentConversationState{}}
+		tracker.agents[userID] = agent
+	}
+	update(agent)
+	agent.UpdatedAt = time.Now().UTC()
+	change := AgentStateChange{Previous: previous, Current: *agent.clone(), Topic: topic}
+	tracker.mutex.Unlock()
+	tracker.logger.Scope("update").Debugf("Agent %s updated from %s", userID, topic)
+	tracker.notify(change)
+	return true
+}
+
+// Get gets a snapshot of the state of the given agent
+func (tracker *AgentStateTracker) Get(user Identifiable) (AgentState, bool) {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	if agent, found := tracker.agents[user.GetID()]; found {
+		return *agent.clone(), true
+	}
+	return AgentState{}, false
+}
+
+// Snapshot gets a snapshot of all the tracked agents
+func (tracker *AgentStateTracker) Snapshot() []AgentState {
+	return tracker.Find(func(AgentState) bool { return true })
+}
+
+// Find gets a snapshot of the tracked agents that match the given predicate
+func (tracker *AgentStateTracker) Find(match func(agent AgentState) bool) []AgentState {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	agents := []AgentState{}
+	for _, agent := range tracker.agents {
+		if snapshot := *agent.clone(); match(snapshot) {
+			agents = append(agents, snapshot)
+		}
+	}
+	return agents
+}
+
+// AvailableOn gets a snapshot of the agents that are available on the given queue
+//
+// See AgentState.IsAvailable and AgentState.IsOnQueue
+func (tracker *AgentStateTracker) AvailableOn(queue Identifiable) []AgentState {
+	return tracker.Find(func(agent AgentState) bool {
+		return agent.IsAvailable() && agent.IsOnQueue(queue)
+	})
+}
+
+// Changes gets a chan that receives the changes of the tracked agents
+//
+// Changes are dropped if the chan is full, the buffer should be sized accordingly.
+// Call the returned func to stop receiving changes, the chan is closed then.
+func (tracker *AgentStateTracker) Changes(buffer int) (<-chan AgentStateChange, func()) {
+	changes := make(chan AgentStateChange, buffer)
+	tracker.mutex.Lock()
+	tracker.subscribers[changes] = struct{}{}
+	tracker.mutex.Unlock()
+	var once sync.Once
+	return changes, func() {
+		once.Do(func() {
+			tracker.mutex.Lock()
+			delete(tracker.subscribers, changes)
+			close(changes)
+			tracker.mutex.Unlock()
+		})
+	}
+}
+
+// notify sends changes to the subscribers
+func (tracker *AgentStateTracker) notify(changes ...AgentStateChange) {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	for _, change := range changes {
+		for subscriber := range tracker.subscribers {
+			select {
+			case subscriber <- change:
+			default:
+				tracker.logger.Warnf("Dropped a change of agent %s, the subscriber is too slow", change.Current.User.ID)
+			}
+		}
+	}
+}
+
+// IsAvailable tells if the agent is on queue and not interacting
+func (agent AgentState) IsAvailable() bool {
+	return agent.RoutingStatus != nil && agent.RoutingStatus.Status == "IDLE"
+}
+
+// IsOnQueue tells if the agent has joined the given queue
+func (agent AgentState) IsOnQueue(queue Identifiable) bool {
+	return slices.Contains(agent.ActiveQueueIDs, queue.GetID())
+}
+
+// GetID gets the identifier of this
+//
+//	implements Identifiable
+func (agent AgentState) GetID() uuid.UUID {
+	if agent.User == nil {
+		return uuid.Nil
+	}
+	return agent.User.ID
+}
+
+// clone creates a copy of this that does not share its map and slice
+func (agent *AgentState) clone() *AgentState {
+	clone := *agent
+	clone.ActiveQueueIDs = slices.Clone(agent.ActiveQueueIDs)
+	clone.Conversations = make(map[uuid.UUID]*AgentConversationState, len(agent.Conversations))
+	for id, conversation := range agent.Conversations {
+		copied := *conversation
+		clone.Conversations[id] = &copied
+	}
+	return &clone
+}
@@ -0,0 +1,211 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type AgentStateTrackerSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestAgentStateTrackerSuite(t *testing.T) {
+	suite.Run(t, new(AgentStateTrackerSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *AgentStateTrackerSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *AgentStateTrackerSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *AgentStateTrackerSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *AgentStateTrackerSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *AgentStateTrackerSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+// *****************************************************************************
+
+func (suite *AgentStateTrackerSuite) TestCanResync() {
+	agentID := uuid.MustParse("6408f799-973a-436a-9e1a-a75a6ddc46f5")
+	queueID := uuid.MustParse("3c9d1b2a-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Logger.Infof("Request: %s %s", r.Method, r.URL.String())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v2/users/":
+			suite.Assert().Equal("presence,routingStatus,station", r.URL.Query().Get("expand"))
+			fmt.Fprintf(w, `{"entities": [{"id": "%s", "name": "John Doe", "routingStatus": {"status": "IDLE"}, "presence": {"presenceDefinition": {"systemPresence": "On Queue"}}}, {"id": "%s", "name": "Jane Doe", "routingStatus": {"status": "OFF_QUEUE"}}], "pageCount": 1}`, agentID, uuid.New())
+		case fmt.Sprintf("/api/v2/routing/queues/%s/members", queueID):
+			suite.Assert().Equal("true", r.URL.Query().Get("joined"))
+			fmt.Fprintf(w, `{"entities": [{"id": "%s", "joined": true}], "pageCount": 1}`, agentID)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tracker := gcloudcx.NewAgentStateTracker(CreateTestClient(server.URL, suite.Logger), gcloudcx.AgentStateTrackerOptions{
+		Queues: []gcloudcx.Identifiable{gcloudcx.Queue{ID: queueID}},
+	})
+	changes, stop := tracker.Changes(10)
+	defer stop()
+
+	_, err := tracker.Resync(context.Background())
+	suite.Require().NoErrorf(err, "Failed to resync. %s", err)
+	suite.Assert().Len(tracker.Snapshot(), 2)
+	suite.Assert().Len(changes, 2)
+
+	available := tracker.AvailableOn(gcloudcx.Queue{ID: queueID})
+	suite.Require().Len(available, 1)
+	suite.Assert().Equal(agentID, available[0].GetID())
+	suite.Assert().Equal("John Doe", available[0].User.Name)
+	suite.Assert().Empty(tracker.AvailableOn(gcloudcx.Queue{ID: uuid.New()}))
+}
+
+func (suite *AgentStateTrackerSuite) TestCanTrackNotifications() {
+	agentID := uuid.MustParse("6408f799-973a-436a-9e1a-a75a6ddc46f5")
+	queueID := uuid.MustParse("3c9d1b2a-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+	conversationID := uuid.MustParse("aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e")
+	tracker := gcloudcx.NewAgentStateTracker(nil, gcloudcx.AgentStateTrackerOptions{
+		Users:  []gcloudcx.Identifiable{gcloudcx.User{ID: agentID}},
+		Logger: suite.Logger,
+	})
+	changes, stop := tracker.Changes(10)
+	defer stop()
+
+	topic, err := gcloudcx.UnmarshalNotificationTopic(suite.LoadTestData("notification_topic_user_activity.json"))
+	suite.Require().NoErrorf(err, "Failed to unmarshal topic. %s", err)
+	suite.Require().True(tracker.Handle(topic), "The tracker should handle the activity topic")
+
+	change := <-changes
+	suite.Assert().Nil(change.Previous)
+	suite.Assert().Equal(topic, change.Topic)
+	suite.Require().NotNil(change.Current.RoutingStatus)
+	suite.Assert().Equal("IDLE", change.Current.RoutingStatus.Status)
+	suite.Require().NotNil(change.Current.Presence)
+	suite.Require().NotNil(change.Current.Presence.Definition)
+	suite.Assert().Equal("On Queue", change.Current.Presence.Definition.SystemPresence)
+	suite.Assert().Equal([]uuid.UUID{queueID}, change.Current.ActiveQueueIDs)
+	suite.Assert().Len(tracker.AvailableOn(gcloudcx.Queue{ID: queueID}), 1)
+
+	topic, err = gcloudcx.UnmarshalNotificationTopic(suite.LoadTestData("notification_topic_user_conversation_call.json"))
+	suite.Require().NoErrorf(err, "Failed to unmarshal topic. %s", err)
+	suite.Require().True(tracker.Handle(topic), "The tracker should handle the call topic")
+
+	change = <-changes
+	suite.Require().NotNil(change.Previous)
+	suite.Assert().Empty(change.Previous.Conversations)
+	suite.Require().Contains(change.Current.Conversations, conversationID)
+	conversation := change.Current.Conversations[conversationID]
+	suite.Assert().Equal("call", conversation.MediaType)
+	suite.Assert().Equal(gcloudcx.ConversationStateAlerting, conversation.State)
+	suite.Assert().Equal(queueID, conversation.QueueID)
+
+	suite.Assert().False(tracker.Handle(gcloudcx.UserPresenceTopic{User: &gcloudcx.User{ID: uuid.New()}}), "The tracker should ignore untracked users")
+	suite.Assert().False(tracker.Handle(gcloudcx.MetadataTopic{}), "The tracker should ignore other topics")
+	suite.Assert().Len(tracker.Snapshot(), 1)
+}
+
+func (suite *AgentStateTrackerSuite) TestShouldRemoveEndedConversationsWhenResyncing() {
+	agentID := uuid.MustParse("6408f799-973a-436a-9e1a-a75a6ddc46f5")
+	conversationID := uuid.MustParse("aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e")
+	ended := false
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/users/%s", agentID): map[string]any{"id": agentID, "name": "John Doe", "routingStatus": map[string]any{"status": "INTERACTING"}},
+		fmt.Sprintf("GET /api/v2/conversations/%s", conversationID): http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ended {
+				core.RespondWithJSON(w, http.StatusNotFound, map[string]any{"status": http.StatusNotFound, "code": "not.found", "message": "The requested resource was not found"})
+				return
+			}
+			core.RespondWithJSON(w, http.StatusOK, map[string]any{
+				"id": conversationID,
+				"participants": []map[string]any{
+					{"id": uuid.New(), "purpose": "customer", "state": "connected"},
+					{"id": uuid.New(), "purpose": "agent", "state": "connected", "user": map[string]any{"id": agentID}},
+				},
+			})
+		}),
+	})
+	defer server.Close()
+	tracker := gcloudcx.NewAgentStateTracker(CreateTestClient(server.URL, suite.Logger), gcloudcx.AgentStateTrackerOptions{
+		Users:  []gcloudcx.Identifiable{gcloudcx.User{ID: agentID}},
+		Logger: suite.Logger,
+	})
+
+	topic, err := gcloudcx.UnmarshalNotificationTopic(suite.LoadTestData("notification_topic_user_conversation_call.json"))
+	suite.Require().NoErrorf(err, "Failed to unmarshal topic. %s", err)
+	suite.Require().True(tracker.Handle(topic), "The tracker should handle the call topic")
+	agent, found := tracker.Get(gcloudcx.User{ID: agentID})
+	suite.Require().True(found)
+	suite.Require().Contains(agent.Conversations, conversationID)
+	suite.Assert().Equal(gcloudcx.ConversationStateAlerting, agent.Conversations[conversationID].State)
+
+	_, err = tracker.Resync(context.Background())
+	suite.Require().NoErrorf(err, "Failed to resync. %s", err)
+	agent, _ = tracker.Get(gcloudcx.User{ID: agentID})
+	suite.Require().Contains(agent.Conversations, conversationID, "An active conversation should be kept")
+	suite.Assert().Equal(gcloudcx.ConversationStateConnected, agent.Conversations[conversationID].State)
+	suite.Assert().Equal("call", agent.Conversations[conversationID].MediaType)
+
+	ended = true // the disconnect notification was missed
+	_, err = tracker.Resync(context.Background())
+	suite.Require().NoErrorf(err, "Failed to resync. %s", err)
+	agent, _ = tracker.Get(gcloudcx.User{ID: agentID})
+	suite.Assert().Empty(agent.Conversations, "An ended conversation should be removed")
+}
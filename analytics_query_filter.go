@@ -0,0 +1,135 @@
+package gcloudcx
+
+import (
+	"github.com/gildas/go-errors"
+)
+
+// AnalyticsQueryFilter describes a filter of an analytics query
+//
+// The predicates and clauses of the filter are combined with its Type ("and" or "or").
+//
+// See: https://developer.genesys.cloud/analyticsdatamanagement/analytics/detail/
+type AnalyticsQueryFilter struct {
+	Type       string                    `json:"type"` // and, or
+	Clauses    []AnalyticsQueryClause    `json:"clauses,omitempty"`
+	Predicates []AnalyticsQueryPredicate `json:"predicates,omitempty"`
+}
+
+// AnalyticsQueryClause describes a group of predicates of an AnalyticsQueryFilter
+type AnalyticsQueryClause struct {
+	Type       string                    `json:"type"` // and, or
+	Predicates []AnalyticsQueryPredicate `json:"predicates"`
+}
+
+// AnalyticsQueryPredicate describes a predicate of an AnalyticsQueryFilter
+type AnalyticsQueryPredicate struct {
+	Type      string `json:"type,omitempty"` // dimension, property, metric
+	Dimension string `json:"dimension,omitempty"`
+	Operator  string `json:"operator,omitempty"` // matches, exists, notExists
+	Value     string `json:"value,omitempty"`
+}
+
+// NewAnalyticsQueryFilter creates a new AnalyticsQueryFilter that matches all the given dimension/value pairs
+//
+// Example:
+//
+//	filter := gcloudcx.NewAnalyticsQueryFilter("queueId", queue.ID.String(), "mediaType", "voice")
+func NewAnalyticsQueryFilter(dimensionValues ...string) AnalyticsQueryFilter {
+	filter := AnalyticsQueryFilter{Type: AnalyticsFilterAnd}
+	for i := 0; i+1 < len(dimensionValues); i += 2 {
+		filter.Predicates = append(filter.Predicates, AnalyticsQueryPredicate{
+			Type:      "dimension",
+			Dimension: dimensionValues[i],
+			Operator:  "matches",
+			Value:     dimensionValues[i+1],
+		})
+	}
+	return filter
+}
+
+// Analytics query filter and clause types
+const (
+	AnalyticsFilterAnd = "and"
+	AnalyticsFilterOr  = "or"
+)
+
+// NewAnalyticsAndFilter creates a new AnalyticsQueryFilter that matches when all its predicates and clauses match
+//
+// Example:
+//
+//	filter := gcloudcx.NewAnalyticsAndFilter().MatchesAny("queueId", queueIDs...).Matches("mediaType", "voice")
+func NewAnalyticsAndFilter() *AnalyticsQueryFilter {
+	return &AnalyticsQueryFilter{Type: AnalyticsFilterAnd}
+}
+
+// NewAnalyticsOrFilter creates a new AnalyticsQueryFilter that matches when any of its predicates and clauses match
+func NewAnalyticsOrFilter() *AnalyticsQueryFilter {
+	return &AnalyticsQueryFilter{Type: AnalyticsFilterOr}
+}
+
+// Matches adds a predicate that matches a dimension with a value
+func (filter *AnalyticsQueryFilter) Matches(dimension, value string) *AnalyticsQueryFilter {
+	filter.Predicates = append(filter.Predicates, AnalyticsQueryPredicate{Type: "dimension", Dimension: dimension, Operator: "matches", Value: value})
+	return filter
+}
+
+// Exists adds a predicate that matches when a dimension has a value
+func (filter *AnalyticsQueryFilter) Exists(dimension string) *AnalyticsQueryFilter {
+	filter.Predicates = append(filter.Predicates, AnalyticsQueryPredicate{Type: "dimension", Dimension: dimension, Operator: "exists"})
+	return filter
+}
+
+// NotExists adds a predicate that matches when a dimension has no value
+func (filter *AnalyticsQueryFilter) NotExists(dimension string) *AnalyticsQueryFilter {
+	filter.Predicates = append(filter.Predicates, AnalyticsQueryPredicate{Type: "dimension", Dimension: dimension, Operator: "notExists"})
+	return filter
+}
+
+// MatchesAny adds a clause that matches a dimension with any of the values
+//
+// If there is only one value, a predicate is added instead.
+func (filter *AnalyticsQueryFilter) MatchesAny(dimension string, values ...string) *AnalyticsQueryFilter {
+	if len(values) == 1 {
+		return filter.Matches(dimension, values[0])
+	}
+	if len(values) == 0 {
+		return filter
+	}
+	clause := AnalyticsQueryClause{Type: AnalyticsFilterOr, Predicates: make([]AnalyticsQueryPredicate, 0, len(values))}
+	for _, value := range values {
+		clause.Predicates = append(clause.Predicates, AnalyticsQueryPredicate{Type: "dimension", Dimension: dimension, Operator: "matches", Value: value})
+	}
+	filter.Clauses = append(filter.Clauses, clause)
+	return filter
+}
+
+// WithClause adds a clause that combines the given predicates with the clause type ("and" or "or")
+func (filter *AnalyticsQueryFilter) WithClause(clauseType string, predicates ...AnalyticsQueryPredicate) *AnalyticsQueryFilter {
+	filter.Clauses = append(filter.Clauses, AnalyticsQueryClause{Type: clauseType, Predicates: predicates})
+	return filter
+}
+
+// IsEmpty tells if this filter has no predicate and no clause
+func (filter AnalyticsQueryFilter) IsEmpty() bool {
+	return len(filter.Predicates) == 0 && len(filter.Clauses) == 0
+}
+
+// Validate validates this filter
+func (filter AnalyticsQueryFilter) Validate() error {
+	var merr errors.MultiError
+	if filter.Type != AnalyticsFilterAnd && filter.Type != AnalyticsFilterOr {
+		merr.Append(errors.ArgumentInvalid.With("type", filter.Type, "and, or"))
+	}
+	if filter.IsEmpty() {
+		merr.Append(errors.ArgumentMissing.With("predicates"))
+	}
+	for _, clause := range filter.Clauses {
+		if clause.Type != AnalyticsFilterAnd && clause.Type != AnalyticsFilterOr {
+			merr.Append(errors.ArgumentInvalid.With("clause.type", clause.Type, "and, or"))
+		}
+		if len(clause.Predicates) == 0 {
+			merr.Append(errors.ArgumentMissing.With("clause.predicates"))
+		}
+	}
+	return merr.AsError()
+}
@@ -23,6 +23,7 @@ type Client struct {
 	Grant          Authorizable   `json:"-"`
 	RequestTimeout time.Duration  `json:"requestTimout"`
 	Logger         *logger.Logger `json:"-"`
+	presences      *PresenceCatalog
 }
 
 // ClientOptions contains the options to create a new Client
@@ -58,6 +59,7 @@ func NewClient(options *ClientOptions) *Client {
 		Grant:          options.Grant,
 		RequestTimeout: options.RequestTimeout,
 	}
+	client.presences = NewPresenceCatalog(&client, DefaultPresenceCatalogTTL)
 	return client.SetLogger(options.Logger).SetRegion(options.Region)
 }
 
@@ -120,3 +122,13 @@ func (client *Client) CheckScopesWithID(context context.Context, id core.Identif
 	permitted, denied = subject.CheckScopes(scopes...)
 	return permitted, denied, correlationID, nil
 }
+
+// checkInitialized checks if a resource was initialized with a Client to send its requests
+//
+// resource is the name of the resource in the error, e.g. "Queue"
+func checkInitialized(client *Client, resource string, id uuid.UUID) error {
+	if client == nil {
+		return errors.Join(errors.Errorf("%s %s is not initialized", resource, id), errors.ArgumentMissing.With("client"))
+	}
+	return nil
+}
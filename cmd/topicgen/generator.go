@@ -0,0 +1,547 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+// SampleID is the identifier used to build topic names in the generated tests
+const SampleID = "6408f799-973a-436a-9e1a-a75a6ddc46f5"
+
+// SampleCorrelationID is the correlation identifier used in the generated tests
+const SampleCorrelationID = "ca138a93-3198-43b4-8ef4-bced8d38b3b9"
+
+// Generator generates Go code for Notification Topics from their JSON schemas
+type Generator struct {
+	Package    string
+	ImportPath string
+	Prefixes   []string
+	Generated  []string
+	Skipped    []string
+
+	existingTopics map[string]string // topic id -> file name
+	existingTypes  map[string]bool
+	typeNames      map[string]bool
+	refs           map[string]string // schema id -> Go type name
+	structs        []*structType
+	usesTime       bool
+}
+
+type structType struct {
+	Name   string
+	Fields []structField
+}
+
+type structField struct {
+	Name    string
+	Type    string
+	JSON    string
+	Comment string
+}
+
+// LoadDefinitions loads the Notification Topic definitions from a file
+//
+// The file can contain the raw output of the API ({"entities": [...]}) or a JSON array of definitions
+func LoadDefinitions(path string) ([]gcloudcx.NotificationTopicDefinition, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	payload = bytes.TrimSpace(payload)
+	if bytes.HasPrefix(payload, []byte("[")) {
+		var definitions []gcloudcx.NotificationTopicDefinition
+		if err = json.Unmarshal(payload, &definitions); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return definitions, nil
+	}
+	var results struct {
+		Entities []gcloudcx.NotificationTopicDefinition `json:"entities"`
+	}
+	if err = json.Unmarshal(payload, &results); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return results.Entities, nil
+}
+
+// ScanExisting scans the Go files of the given folder for existing types and topics
+//
+// Generated files and the output file are ignored so the generator can be run again.
+func (generator *Generator) ScanExisting(folder string, output string) error {
+	generator.existingTopics = map[string]string{}
+	generator.existingTypes = map[string]bool{}
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return err
+	}
+	fileset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == filepath.Base(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fileset, filepath.Join(folder, name), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		if ast.IsGenerated(file) {
+			continue
+		}
+		for _, declaration := range file.Decls {
+			switch declaration := declaration.(type) {
+			case *ast.GenDecl:
+				for _, spec := range declaration.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						generator.existingTypes[typeSpec.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if topicID, ok := topicTypeOf(declaration); ok {
+					generator.existingTopics[topicID] = name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// topicTypeOf gets the topic identifier returned by a GetType method
+func topicTypeOf(function *ast.FuncDecl) (string, bool) {
+	if function.Recv == nil || function.Name.Name != "GetType" || function.Body == nil || len(function.Body.List) != 1 {
+		return "", false
+	}
+	statement, ok := function.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(statement.Results) != 1 {
+		return "", false
+	}
+	literal, ok := statement.Results[0].(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	return value, err == nil
+}
+
+// Generate generates the Go code and the Go tests for the given definitions
+func (generator *Generator) Generate(definitions []gcloudcx.NotificationTopicDefinition) (code []byte, tests []byte, err error) {
+	if len(generator.Package) == 0 {
+		generator.Package = "gcloudcx"
+	}
+	if len(generator.ImportPath) == 0 {
+		generator.ImportPath = "github.com/gildas/go-gcloudcx"
+	}
+	if generator.existingTopics == nil {
+		generator.existingTopics = map[string]string{}
+	}
+	if generator.existingTypes == nil {
+		generator.existingTypes = map[string]bool{}
+	}
+	generator.typeNames = map[string]bool{}
+	generator.refs = map[string]string{}
+	generator.usesTime = false
+	generator.Generated = []string{}
+	generator.Skipped = []string{}
+
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].ID < definitions[j].ID })
+
+	var body, testBody bytes.Buffer
+	for _, definition := range definitions {
+		if !generator.accepts(definition.ID) {
+			continue
+		}
+		if filename, found := generator.existingTopics[definition.ID]; found {
+			generator.Skipped = append(generator.Skipped, fmt.Sprintf("%s (implemented in %s)", definition.ID, filename))
+			continue
+		}
+		typeName := TopicTypeName(definition.ID)
+		if generator.existingTypes[typeName] || generator.typeNames[typeName] {
+			return nil, nil, fmt.Errorf("type %s for topic %s already exists", typeName, definition.ID)
+		}
+		generator.typeNames[typeName] = true
+		generator.structs = []*structType{}
+		bodyType := generator.goType(definition.Schema, strings.TrimSuffix(typeName, "Topic"), typeName+"EventBody")
+		generator.writeTopic(&body, definition, typeName, bodyType)
+		generator.writeTest(&testBody, definition, typeName)
+		generator.Generated = append(generator.Generated, definition.ID)
+	}
+
+	if len(generator.Generated) == 0 {
+		return nil, nil, fmt.Errorf("no topic to generate")
+	}
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "// Code generated by topicgen; DO NOT EDIT.\n\npackage %s\n\n", generator.Package)
+	header.WriteString("import (\n\t\"encoding/json\"\n")
+	if generator.usesTime {
+		header.WriteString("\t\"time\"\n")
+	}
+	header.WriteString("\n\t\"github.com/gildas/go-errors\"\n)\n")
+	if code, err = format.Source(append(header.Bytes(), body.Bytes()...)); err != nil {
+		return nil, nil, fmt.Errorf("failed to format the generated code: %w", err)
+	}
+
+	var testHeader bytes.Buffer
+	fmt.Fprintf(&testHeader, "// Code generated by topicgen; DO NOT EDIT.\n\npackage %s_test\n\nimport (\n\t%q\n)\n", generator.Package, generator.ImportPath)
+	if tests, err = format.Source(append(testHeader.Bytes(), testBody.Bytes()...)); err != nil {
+		return nil, nil, fmt.Errorf("failed to format the generated tests: %w", err)
+	}
+	return code, tests, nil
+}
+
+func (generator *Generator) accepts(topicID string) bool {
+	if len(generator.Prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range generator.Prefixes {
+		if strings.HasPrefix(topicID, strings.TrimSpace(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (generator *Generator) writeTopic(buffer *bytes.Buffer, definition gcloudcx.NotificationTopicDefinition, typeName, bodyType string) {
+	fmt.Fprintf(buffer, "\n// %s describes the Notification Topic %s\n", typeName, definition.ID)
+	if len(definition.Description) > 0 {
+		buffer.WriteString("//\n")
+		description := strings.TrimSpace(definition.Description)
+		if !strings.HasSuffix(description, ".") {
+			description += "." // so gofmt does not turn a single line into a heading
+		}
+		for _, line := range strings.Split(description, "\n") {
+			fmt.Fprintf(buffer, "// %s\n", strings.TrimSpace(line))
+		}
+	}
+	if len(definition.Permissions) > 0 {
+		fmt.Fprintf(buffer, "//\n// Required permissions: %s\n", strings.Join(definition.Permissions, ", "))
+	}
+	fmt.Fprintf(buffer, "type %s struct {\n\tName string\n\tEventBody %s\n\tCorrelationID string\n\tTargets []Identifiable\n}\n", typeName, bodyType)
+
+	for _, structure := range generator.structs {
+		fmt.Fprintf(buffer, "\n// %s describes the event body (or a part of it) of %s\ntype %s struct {\n", structure.Name, typeName, structure.Name)
+		for _, field := range structure.Fields {
+			fmt.Fprintf(buffer, "\t%s %s `json:\"%s,omitempty\"`", field.Name, field.Type, field.JSON)
+			if len(field.Comment) > 0 {
+				fmt.Fprintf(buffer, " // %s", field.Comment)
+			}
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString("}\n")
+	}
+
+	// Like the hand-written topics, a topic name without its targets is rejected
+	targetsCheck := "!found"
+	if strings.Contains(definition.ID, "{id}") {
+		targetsCheck = "!found || len(targets) == 0"
+	}
+	fmt.Fprintf(buffer, `
+func init() {
+	notificationTopicRegistry.Add(%[1]s{})
+}
+
+// GetType returns the type of this topic
+//
+// implements core.TypeCarrier
+func (topic %[1]s) GetType() string {
+	return %[2]q
+}
+
+// GetTargets returns the targets of this topic
+func (topic %[1]s) GetTargets() []Identifiable {
+	return topic.Targets
+}
+
+// With creates a new NotificationTopic with the given targets
+func (topic %[1]s) With(targets ...Identifiable) NotificationTopic {
+	newTopic := topic
+	newTopic.Targets = targets
+	return newTopic
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (topic %[1]s) String() string {
+	if len(topic.Targets) == 0 {
+		return topic.GetType()
+	}
+	return topicNameWith(topic, topic.Targets...)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (topic *%[1]s) UnmarshalJSON(payload []byte) (err error) {
+	var inner struct {
+		TopicName string `+"`json:\"topicName\"`"+`
+		EventBody %[3]s `+"`json:\"eventBody\"`"+`
+		Metadata  struct {
+			CorrelationID string `+"`json:\"correlationId,omitempty\"`"+`
+		} `+"`json:\"metadata,omitempty\"`"+`
+	}
+	if err = json.Unmarshal(payload, &inner); err != nil {
+		return errors.JSONUnmarshalError.Wrap(err)
+	}
+	found, targets := getTargets(topic.GetType(), inner.TopicName)
+	if %[4]s {
+		return errors.JSONUnmarshalError.Wrap(errors.ArgumentInvalid.With("topicName", inner.TopicName))
+	}
+	topic.Name = inner.TopicName
+	topic.EventBody = inner.EventBody
+	topic.CorrelationID = inner.Metadata.CorrelationID
+	topic.Targets = targets
+	return
+}
+`, typeName, definition.ID, bodyType, targetsCheck)
+}
+
+func (generator *Generator) writeTest(buffer *bytes.Buffer, definition gcloudcx.NotificationTopicDefinition, typeName string) {
+	topicName := strings.ReplaceAll(definition.ID, "{id}", SampleID)
+	payload, _ := json.MarshalIndent(map[string]any{
+		"topicName": topicName,
+		"version":   "2",
+		"eventBody": sample(definition.Schema, 0),
+		"metadata":  map[string]any{"correlationId": SampleCorrelationID},
+	}, "\t\t", "  ")
+	literal := "`" + string(payload) + "`"
+	if bytes.ContainsRune(payload, '`') {
+		literal = strconv.Quote(string(payload))
+	}
+	fmt.Fprintf(buffer, `
+func (suite *NotificationTopicSuite) TestCanUnmarshal%[1]s() {
+	payload := []byte(%[2]s)
+
+	topic, err := gcloudcx.UnmarshalNotificationTopic(payload)
+	suite.Require().NoErrorf(err, "Failed to Unmarshal Notification Topic. %%s", err)
+	suite.Require().NotNil(topic, "Unmarshal Notification Topic returned nil")
+
+	actual, ok := topic.(gcloudcx.%[1]s)
+	suite.Require().Truef(ok, "Expected a %[1]s, got %%T", topic)
+	suite.Assert().Equal(%[3]q, actual.String())
+	suite.Assert().Equal(%[4]q, actual.CorrelationID)
+}
+`, typeName, literal, topicName, SampleCorrelationID)
+}
+
+// goType gets the Go type of a JSON schema, collecting the struct types to generate
+func (generator *Generator) goType(schema map[string]any, parent, name string) string {
+	if schema == nil {
+		return "json.RawMessage"
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if typeName, found := generator.refs[ref]; found {
+			return "*" + typeName
+		}
+		return "json.RawMessage"
+	}
+	switch schema["type"] {
+	case "object":
+		properties, _ := schema["properties"].(map[string]any)
+		if len(properties) == 0 {
+			if additional, ok := schema["additionalProperties"].(map[string]any); ok {
+				return "map[string]" + generator.goType(additional, parent, name+"Value")
+			}
+			return "map[string]any"
+		}
+		name = generator.uniqueTypeName(name)
+		if id, ok := schema["id"].(string); ok && len(id) > 0 {
+			generator.refs[id] = name
+		}
+		structure := &structType{Name: name}
+		generator.structs = append(generator.structs, structure)
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fieldNames := map[string]bool{}
+		for _, key := range keys {
+			property, _ := properties[key].(map[string]any)
+			fieldName := GoName(key)
+			for fieldNames[fieldName] {
+				fieldName += "_"
+			}
+			fieldNames[fieldName] = true
+			structure.Fields = append(structure.Fields, structField{
+				Name:    fieldName,
+				Type:    generator.goType(property, parent, parent+fieldName),
+				JSON:    key,
+				Comment: enumComment(property),
+			})
+		}
+		return name
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		return "[]" + generator.goType(items, parent, singular(name))
+	case "string":
+		if schema["format"] == "date-time" {
+			generator.usesTime = true
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "any":
+		return "any"
+	}
+	return "json.RawMessage"
+}
+
+func (generator *Generator) uniqueTypeName(name string) string {
+	candidate := name
+	for index := 2; generator.typeNames[candidate] || generator.existingTypes[candidate]; index++ {
+		candidate = fmt.Sprintf("%s%d", name, index)
+	}
+	generator.typeNames[candidate] = true
+	return candidate
+}
+
+// sample builds a sample value for the given JSON schema
+func sample(schema map[string]any, depth int) any {
+	if schema == nil || depth > 5 {
+		return nil
+	}
+	if _, ok := schema["$ref"]; ok {
+		return nil
+	}
+	switch schema["type"] {
+	case "object":
+		properties, _ := schema["properties"].(map[string]any)
+		value := map[string]any{}
+		for key, property := range properties {
+			property, _ := property.(map[string]any)
+			value[key] = sample(property, depth+1)
+		}
+		return value
+	case "array":
+		items, _ := schema["items"].(map[string]any)
+		if items == nil {
+			return []any{}
+		}
+		return []any{sample(items, depth+1)}
+	case "string":
+		if values, ok := schema["enum"].([]any); ok && len(values) > 0 {
+			return values[0]
+		}
+		if schema["format"] == "date-time" {
+			return "2024-06-18T16:59:09.689Z"
+		}
+		return "string"
+	case "integer":
+		return 1
+	case "number":
+		return 1.5
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+func enumComment(schema map[string]any) string {
+	values, ok := schema["enum"].([]any)
+	if !ok || len(values) == 0 {
+		return ""
+	}
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		items = append(items, fmt.Sprintf("%v", value))
+	}
+	return strings.Join(items, ",")
+}
+
+var initialisms = map[string]string{
+	"acd": "ACD", "acw": "ACW", "ani": "ANI", "api": "API", "dnis": "DNIS", "http": "HTTP",
+	"id": "ID", "ids": "IDs", "ip": "IP", "ivr": "IVR", "json": "JSON", "sms": "SMS",
+	"uri": "URI", "url": "URL", "utc": "UTC", "uui": "UUI", "uuid": "UUID",
+}
+
+// GoName converts a JSON property or topic segment into an exported Go name
+//
+// e.g.: "conversationId" -> "ConversationID", "wrapup-codes" -> "WrapupCodes"
+func GoName(value string) string {
+	words := []string{}
+	current := []rune{}
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = []rune{}
+		}
+	}
+	for _, r := range value {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(current) > 0 && !unicode.IsUpper(current[len(current)-1]):
+			flush()
+			current = append(current, r)
+		case unicode.IsLower(r) && len(current) > 1 && unicode.IsUpper(current[len(current)-1]) && unicode.IsUpper(current[len(current)-2]):
+			// "ACDOutcome" -> "ACD", "Outcome"
+			last := current[len(current)-1]
+			current = current[:len(current)-1]
+			flush()
+			current = append(current, last, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+
+	var name strings.Builder
+	for _, word := range words {
+		if initialism, found := initialisms[strings.ToLower(word)]; found {
+			name.WriteString(initialism)
+			continue
+		}
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		name.WriteString(string(runes))
+	}
+	if name.Len() == 0 {
+		return "Value"
+	}
+	if result := name.String(); unicode.IsDigit([]rune(result)[0]) {
+		return "N" + result
+	}
+	return name.String()
+}
+
+// TopicTypeName gets the Go type name of a topic
+//
+// e.g.: "v2.routing.queues.{id}.users" -> "RoutingQueuesUsersTopic"
+func TopicTypeName(topicID string) string {
+	var name strings.Builder
+	for _, segment := range strings.Split(topicID, ".") {
+		if segment == "v2" || segment == "{id}" {
+			continue
+		}
+		name.WriteString(GoName(segment))
+	}
+	name.WriteString("Topic")
+	return name.String()
+}
+
+func singular(name string) string {
+	if strings.HasSuffix(name, "ies") {
+		return strings.TrimSuffix(name, "ies") + "y"
+	}
+	if strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 3 {
+		return strings.TrimSuffix(name, "s")
+	}
+	return name + "Item"
+}
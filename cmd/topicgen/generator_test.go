@@ -0,0 +1,75 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GeneratorSuite struct {
+	suite.Suite
+}
+
+func TestGeneratorSuite(t *testing.T) {
+	suite.Run(t, new(GeneratorSuite))
+}
+
+func (suite *GeneratorSuite) TestCanLoadDefinitions() {
+	definitions, err := LoadDefinitions(filepath.Join("testdata", "availabletopics.json"))
+	suite.Require().NoErrorf(err, "Failed to load definitions. %s", err)
+	suite.Require().Len(definitions, 4)
+	suite.Assert().Equal("v2.users.{id}.presence", definitions[0].ID)
+	suite.Assert().NotEmpty(definitions[0].Schema)
+}
+
+func (suite *GeneratorSuite) TestCanConvertNames() {
+	suite.Assert().Equal("ConversationID", GoName("conversationId"))
+	suite.Assert().Equal("SkillIDs", GoName("skillIds"))
+	suite.Assert().Equal("AvatarImageURL", GoName("avatarImageURL"))
+	suite.Assert().Equal("ACDOutcome", GoName("ACDOutcome"))
+	suite.Assert().Equal("WrapupCodes", GoName("wrapup-codes"))
+	suite.Assert().Equal("N911", GoName("911"))
+	suite.Assert().Equal("RoutingQueuesUsersTopic", TopicTypeName("v2.routing.queues.{id}.users"))
+	suite.Assert().Equal("UsersOutofofficeTopic", TopicTypeName("v2.users.{id}.outofoffice"))
+}
+
+func (suite *GeneratorSuite) TestCanGenerate() {
+	definitions, err := LoadDefinitions(filepath.Join("testdata", "availabletopics.json"))
+	suite.Require().NoErrorf(err, "Failed to load definitions. %s", err)
+
+	generator := &Generator{}
+	err = generator.ScanExisting(filepath.Join("..", ".."), "notification_topics_generated.go")
+	suite.Require().NoErrorf(err, "Failed to scan the package. %s", err)
+
+	code, tests, err := generator.Generate(definitions)
+	suite.Require().NoErrorf(err, "Failed to generate. %s", err)
+	suite.Assert().Equal([]string{"v2.analytics.queues.{id}.observations", "v2.routing.queues.{id}.users", "v2.users.{id}.outofoffice"}, generator.Generated)
+	suite.Require().Len(generator.Skipped, 1)
+	suite.Assert().Contains(generator.Skipped[0], "v2.users.{id}.presence")
+
+	suite.Assert().Contains(string(code), "// Code generated by topicgen; DO NOT EDIT.")
+	suite.Assert().Contains(string(code), "notificationTopicRegistry.Add(RoutingQueuesUsersTopic{})")
+	suite.Assert().Contains(string(code), `return "v2.routing.queues.{id}.users"`)
+	suite.Assert().Contains(string(code), "// OFF_QUEUE,IDLE,INTERACTING,NOT_RESPONDING,COMMUNICATING")
+	suite.Assert().Contains(string(code), "Manager *UsersOutofofficeUser")
+	suite.Assert().Contains(string(code), "Group map[string]string")
+	suite.Assert().Contains(string(code), "Data  []AnalyticsQueuesObservationsDataItem")
+	suite.Assert().Contains(string(code), "if !found || len(targets) == 0 {", "Topic names without targets should be rejected")
+	suite.Assert().NotContains(string(code), "if !found {")
+	suite.Assert().Contains(string(tests), "func (suite *NotificationTopicSuite) TestCanUnmarshalRoutingQueuesUsersTopic()")
+}
+
+func (suite *GeneratorSuite) TestCanFilterTopics() {
+	definitions, err := LoadDefinitions(filepath.Join("testdata", "availabletopics.json"))
+	suite.Require().NoErrorf(err, "Failed to load definitions. %s", err)
+
+	generator := &Generator{Prefixes: []string{"v2.routing"}}
+	_, _, err = generator.Generate(definitions)
+	suite.Require().NoErrorf(err, "Failed to generate. %s", err)
+	suite.Assert().Equal([]string{"v2.routing.queues.{id}.users"}, generator.Generated)
+
+	generator = &Generator{Prefixes: []string{"v2.unknown"}}
+	_, _, err = generator.Generate(definitions)
+	suite.Assert().Error(err, "Generating no topic should fail")
+}
@@ -0,0 +1,79 @@
+// Command topicgen generates Notification Topic types from the Genesys Cloud available topics schemas
+//
+// First, save the output of the available topics API with their schemas, for example:
+//
+//	definitions, _, err := client.GetAvailableNotificationTopics(context, "schema", "requiresPermissions")
+//	data, _ := json.Marshal(definitions)
+//	os.WriteFile("availabletopics.json", data, 0644)
+//
+// or, directly from the API:
+//
+//	curl -H "Authorization: Bearer $TOKEN" "https://api.mypurecloud.com/api/v2/notifications/availabletopics?expand=schema,requiresPermissions" > availabletopics.json
+//
+// Then, from the root of the package, run:
+//
+//	go run ./cmd/topicgen -input availabletopics.json -filter v2.routing.queues
+//
+// Topics that are already implemented in the package (by hand) are skipped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		input      = flag.String("input", "", "the file containing the available topics with their schemas (JSON)")
+		output     = flag.String("output", "notification_topics_generated.go", "the Go file to generate")
+		testOutput = flag.String("test-output", "notification_topics_generated_test.go", "the Go test file to generate, empty to skip the tests")
+		source     = flag.String("source", ".", "the folder of the package, used to find the topics that are already implemented")
+		pkg        = flag.String("package", "gcloudcx", "the package name of the generated code")
+		filter     = flag.String("filter", "", "comma separated list of topic prefixes to generate (e.g.: v2.routing.queues,v2.users), all topics if empty")
+	)
+	flag.Parse()
+
+	if len(*input) == 0 {
+		fmt.Fprintln(os.Stderr, "Missing -input")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*input, *output, *testOutput, *source, *pkg, *filter); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output, testOutput, source, pkg, filter string) error {
+	definitions, err := LoadDefinitions(input)
+	if err != nil {
+		return err
+	}
+	generator := &Generator{Package: pkg}
+	if len(filter) > 0 {
+		generator.Prefixes = strings.Split(filter, ",")
+	}
+	if err = generator.ScanExisting(source, output); err != nil {
+		return err
+	}
+	code, tests, err := generator.Generate(definitions)
+	if err != nil {
+		return err
+	}
+	for _, skipped := range generator.Skipped {
+		fmt.Fprintf(os.Stderr, "Skipped %s\n", skipped)
+	}
+	if err = os.WriteFile(output, code, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Generated %d topics in %s\n", len(generator.Generated), output)
+	if len(testOutput) > 0 {
+		if err = os.WriteFile(testOutput, tests, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Generated tests in %s\n", testOutput)
+	}
+	return nil
+}
@@ -16,18 +16,18 @@ import (
 //
 //	See: https://developer.mypurecloud.com/api/rest/v2/conversations
 type Conversation struct {
-	ID              uuid.UUID     `json:"id"`
-	SelfURI         URI           `json:"selfUri,omitempty"`
-	Name            string        `json:"name"`
-	ExternalTag     string        `json:"externalTag,omitempty"`
-	StartTime       time.Time     `json:"startTime"`
-	EndTime         time.Time     `json:"endTime"`
-	Address         string        `json:"address"`
-	Participants    []Participant `json:"participants"`
-	ConversationIDs []uuid.UUID   `json:"conversationIds"`
-	MaxParticipants int           `json:"maxParticipants"`
-	RecordingState  string        `json:"recordingState"`
-	State           string        `json:"state"`
+	ID              uuid.UUID         `json:"id"`
+	SelfURI         URI               `json:"selfUri,omitempty"`
+	Name            string            `json:"name"`
+	ExternalTag     string            `json:"externalTag,omitempty"`
+	StartTime       time.Time         `json:"startTime"`
+	EndTime         time.Time         `json:"endTime"`
+	Address         string            `json:"address"`
+	Participants    []Participant     `json:"participants"`
+	ConversationIDs []uuid.UUID       `json:"conversationIds"`
+	MaxParticipants int               `json:"maxParticipants"`
+	RecordingState  string            `json:"recordingState"`
+	State           ConversationState `json:"state"`
 	Divisions       []struct {
 		Division DomainEntityRef   `json:"division"`
 		Entities []DomainEntityRef `json:"entities"`
@@ -50,11 +50,11 @@ type ConversationRoutingData struct {
 
 // Segment describes a fragment of a Conversation
 type Segment struct {
-	Type           string    `json:"type"`
-	DisconnectType string    `json:"disconnectType"`
-	StartTime      time.Time `json:"startTime"`
-	EndTime        time.Time `json:"endTime"`
-	HowEnded       string    `json:"howEnded"`
+	Type           string         `json:"type"`
+	DisconnectType DisconnectType `json:"disconnectType"`
+	StartTime      time.Time      `json:"startTime"`
+	EndTime        time.Time      `json:"endTime"`
+	HowEnded       string         `json:"howEnded"`
 }
 
 // DisconnectReason describes the reason of a disconnect
@@ -151,8 +151,13 @@ func (conversation Conversation) Disconnect(context context.Context, identifiabl
 
 // UpdateState update the state of an identifiable in this
 //
+// Unknown states are rejected before the request is sent.
+//
 // implements StateUpdater
-func (conversation Conversation) UpdateState(context context.Context, identifiable Identifiable, state string) (correlationID string, err error) {
+func (conversation Conversation) UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error) {
+	if err = state.Validate(); err != nil {
+		return
+	}
 	return conversation.client.Patch(
 		context,
 		NewURI("/conversations/%s/participants/%s", conversation.ID, identifiable.GetID()),
@@ -161,8 +166,20 @@ func (conversation Conversation) UpdateState(context context.Context, identifiab
 	)
 }
 
+// UpdateAttributes updates the attributes of a participant of this, whatever its media type
+//
+// The attributes are merged with the existing ones, attributes that are not given are left untouched.
+//
+// implements AttributesUpdater
+func (conversation Conversation) UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error) {
+	if conversation.client == nil {
+		return "", errors.Join(errors.Errorf("Conversation %s is not initialized", conversation.ID), errors.ArgumentMissing.With("client"))
+	}
+	return updateParticipantAttributes(context, conversation.client, NewURI("/conversations/%s/participants/%s/attributes", conversation.ID, identifiable.GetID()), attributes)
+}
+
 // GetParticipantByPurpose get the conversation's participant by its purpose
-func (conversation Conversation) GetParticipantByPurpose(purpose string) (participant *Participant, found bool) {
+func (conversation Conversation) GetParticipantByPurpose(purpose ParticipantPurpose) (participant *Participant, found bool) {
 	for _, current := range conversation.Participants {
 		if current.Purpose == purpose {
 			return &current, true
@@ -234,7 +251,7 @@ func (conversation Conversation) FetchRecordings(context context.Context) (recor
 
 	// Stitching Conversation to recordings
 	for i := range recordings {
-		recordings[i].Conversation = &conversation
+		recordings[i].Initialize(&conversation, conversation.client, conversation.client.Logger)
 	}
 	return
 }
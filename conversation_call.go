@@ -1,34 +1,40 @@
 package gcloudcx
 
 import (
+	"context"
+	"strings"
 	"time"
 
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
 	"github.com/google/uuid"
 )
 
 // FaxStatus describes a FAX status
 type FaxStatus struct {
-	Direction        string `json:"direction"` // inbound,outbound
-	ActivePage       int    `json:"activePage"`
-	ExpectedPages    int    `json:"expectedPages"`
-	LinesTransmitted int    `json:"linesTransmitted"`
-	BytesTransmitted int    `json:"bytesTransmitted"`
-	BaudRate         int    `json:"baudRate"`
-	PageErrors       int    `json:"pageErrors"`
-	LineErrors       int    `json:"lineErrors"`
+	Direction        Direction `json:"direction"` // inbound,outbound
+	ActivePage       int       `json:"activePage"`
+	ExpectedPages    int       `json:"expectedPages"`
+	LinesTransmitted int       `json:"linesTransmitted"`
+	BytesTransmitted int       `json:"bytesTransmitted"`
+	BaudRate         int       `json:"baudRate"`
+	PageErrors       int       `json:"pageErrors"`
+	LineErrors       int       `json:"lineErrors"`
 }
 
 // ConversationCall describes a Call (like belonging to Participant)
+//
+// When fetched from /api/v2/conversations/calls/{id}, the ID is the Conversation ID and the Participants are set
 type ConversationCall struct {
 	ID                uuid.UUID           `json:"id"`
 	Self              *Address            `json:"self"`
-	Direction         string              `json:"direction"` // inbound,outbound
-	State             string              `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
+	Direction         Direction           `json:"direction"` // inbound,outbound
+	State             ConversationState   `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
 	Muted             bool                `json:"muted"`
 	Held              bool                `json:"held"`
 	Confined          bool                `json:"confined"`
 	Recording         bool                `json:"recording"`
-	RecordingState    string              `json:"recodingState"` // none,active,paused
+	RecordingState    string              `json:"recordingState"` // none,active,paused
 	RecordingID       string              `json:"recordingId"`
 	Segments          []Segment           `json:"segments"`
 	DocumentID        string              `json:"documentId"`
@@ -41,8 +47,448 @@ type ConversationCall struct {
 	DisconnectedTime  time.Time           `json:"disconnectedTime"`
 	StartAlertingTime time.Time           `json:"startAlertingTime"`
 	StartHoldTime     time.Time           `json:"startHoldTime"`
-	DisconnectType    string              `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
+	DisconnectType    DisconnectType      `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
 	DisconnectReasons []*DisconnectReason `json:"disconnectReasons"`
 	FaxStatus         FaxStatus           `json:"faxStatus"`
 	ErrorInfo         ErrorBody           `json:"errorInfo"`
+	Participants      []*Participant      `json:"participants,omitempty"`
+	client            *Client             `json:"-"`
+	logger            *logger.Logger      `json:"-"`
+}
+
+// CallTarget describes the target of a transfer, a consult, or a conference
+//
+// Only one of Address, User, Queue should be set.
+type CallTarget struct {
+	Address   string       // phone number or SIP address
+	Name      string       // the name to display for Address
+	User      Identifiable // a user of the organization
+	Queue     Identifiable // a queue of the organization
+	Voicemail bool         // true to transfer to the voicemail of the User or the Queue
+}
+
+// OutboundCallRequest describes a request to place an outbound call
+//
+// Only one of PhoneNumber, User, Queue, Participants should be set.
+//
+// See: https://developer.genesys.cloud/routing/conversations/conversations-apis#post-api-v2-conversations-calls
+type OutboundCallRequest struct {
+	PhoneNumber   string         // the phone number to call
+	User          Identifiable   // the user to call
+	Queue         Identifiable   // the queue to call
+	Participants  []CallTarget   // the participants of a conference call
+	CallerID      string         // the caller ID phone number
+	CallerIDName  string         // the caller ID name
+	FromQueue     Identifiable   // place the call on behalf of this queue
+	Priority      int            // the priority of the call when calling a queue
+	Language      Identifiable   // the language to route the call with when calling a queue
+	RoutingSkills []Identifiable // the skills to route the call with when calling a queue
+	UUIData       string         // user to user information
+}
+
+// Call consult speak-to modes
+//
+// See: https://developer.genesys.cloud/routing/conversations/conversations-apis#post-api-v2-conversations-calls--conversationId--participants--participantId--consult
+const (
+	ConsultSpeakToDestination = "DESTINATION" // the agent speaks to the consult destination only
+	ConsultSpeakToObject      = "OBJECT"      // the agent speaks to the participant being transferred only
+	ConsultSpeakToBoth        = "BOTH"        // the agent speaks to both (conference)
+)
+
+// PlaceCall places an outbound call
+//
+// The returned ConversationCall only has its ID set, use Fetch to get the call details.
+func (client *Client) PlaceCall(context context.Context, request OutboundCallRequest) (call *ConversationCall, correlationID string, err error) {
+	if len(request.PhoneNumber) == 0 && request.User == nil && request.Queue == nil && len(request.Participants) == 0 {
+		return nil, "", errors.ArgumentMissing.With("phoneNumber")
+	}
+	payload := struct {
+		PhoneNumber      string              `json:"phoneNumber,omitempty"`
+		CallUserID       string              `json:"callUserId,omitempty"`
+		CallQueueID      string              `json:"callQueueId,omitempty"`
+		Participants     []callTargetPayload `json:"participants,omitempty"`
+		CallerID         string              `json:"callerId,omitempty"`
+		CallerIDName     string              `json:"callerIdName,omitempty"`
+		CallFromQueueID  string              `json:"callFromQueueId,omitempty"`
+		Priority         int                 `json:"priority,omitempty"`
+		LanguageID       string              `json:"languageId,omitempty"`
+		RoutingSkillsIDs []string            `json:"routingSkillsIds,omitempty"`
+		UUIData          string              `json:"uuiData,omitempty"`
+	}{
+		PhoneNumber:     request.PhoneNumber,
+		CallUserID:      identifiableID(request.User),
+		CallQueueID:     identifiableID(request.Queue),
+		CallerID:        request.CallerID,
+		CallerIDName:    request.CallerIDName,
+		CallFromQueueID: identifiableID(request.FromQueue),
+		Priority:        request.Priority,
+		LanguageID:      identifiableID(request.Language),
+		UUIData:         request.UUIData,
+	}
+	for _, target := range request.Participants {
+		payload.Participants = append(payload.Participants, target.payload())
+	}
+	for _, skill := range request.RoutingSkills {
+		payload.RoutingSkillsIDs = append(payload.RoutingSkillsIDs, skill.GetID().String())
+	}
+	result := struct {
+		ID uuid.UUID `json:"id"`
+	}{}
+	if correlationID, err = client.Post(context, NewURI("/conversations/calls"), payload, &result); err != nil {
+		return nil, correlationID, err
+	}
+	call = &ConversationCall{}
+	call.Initialize(result.ID, client, client.Logger)
+	return call, correlationID, nil
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (call *ConversationCall) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			call.ID = parameter
+		case *Client:
+			call.client = parameter
+		case *logger.Logger:
+			call.logger = parameter.Child("conversation", "conversation", "id", call.ID, "media", "call")
+		}
+	}
+	if call.logger == nil {
+		call.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (call ConversationCall) GetID() uuid.UUID {
+	return call.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (call ConversationCall) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/conversations/calls/%s", ids[0])
+	}
+	if call.ID != uuid.Nil {
+		return NewURI("/api/v2/conversations/calls/%s", call.ID)
+	}
+	return URI("/api/v2/conversations/calls/")
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (call ConversationCall) String() string {
+	return call.ID.String()
+}
+
+// Disconnect disconnect an Identifiable from this
+//
+// implements Disconnecter
+func (call ConversationCall) Disconnect(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	return call.UpdateState(context, identifiable, "disconnected")
+}
+
+// UpdateState update the state of an identifiable in this
+//
+// Unknown states are rejected before the request is sent.
+//
+// implements StateUpdater
+func (call ConversationCall) UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error) {
+	if err = state.Validate(); err != nil {
+		return
+	}
+	return call.patchParticipant(context, identifiable, struct {
+		State ConversationState `json:"state"`
+	}{State: state})
+}
+
+// Transfer transfers a participant of this Call to the given target without consulting (blind transfer)
+//
+// The target can be a User, a Queue, or a CallTarget.
+//
+// implement Transferrer
+func (call ConversationCall) Transfer(context context.Context, identifiable Identifiable, target Identifiable) (correlationID string, err error) {
+	switch target := target.(type) {
+	case CallTarget:
+		return call.Replace(context, identifiable, target)
+	case *CallTarget:
+		return call.Replace(context, identifiable, *target)
+	case User, *User:
+		return call.Replace(context, identifiable, CallTarget{User: target})
+	case Queue, *Queue:
+		return call.Replace(context, identifiable, CallTarget{Queue: target})
+	}
+	return "", errors.ArgumentInvalid.With("target", target)
+}
+
+// Replace replaces a participant of this Call with the given target
+func (call ConversationCall) Replace(context context.Context, identifiable Identifiable, target CallTarget) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	payload := struct {
+		UserID    string `json:"userId,omitempty"`
+		Address   string `json:"address,omitempty"`
+		UserName  string `json:"userName,omitempty"`
+		QueueID   string `json:"queueId,omitempty"`
+		Voicemail bool   `json:"voicemail,omitempty"`
+	}{
+		UserID:    identifiableID(target.User),
+		Address:   target.Address,
+		UserName:  target.Name,
+		QueueID:   identifiableID(target.Queue),
+		Voicemail: target.Voicemail,
+	}
+	return call.client.Post(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants/%s/replace", call.ID, identifiable.GetID()),
+		payload,
+		nil,
+	)
+}
+
+// Consult starts a consult transfer of a participant of this Call to the given destination
+//
+// speakTo is one of ConsultSpeakToDestination, ConsultSpeakToObject, ConsultSpeakToBoth.
+//
+// Use UpdateConsult to change who the agent speaks to, CancelConsult to cancel the transfer,
+// and Disconnect the agent to complete it.
+func (call ConversationCall) Consult(context context.Context, identifiable Identifiable, destination CallTarget, speakTo string) (destinationParticipantID uuid.UUID, correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	if err = checkConsultSpeakTo(speakTo); err != nil {
+		return
+	}
+	result := struct {
+		DestinationParticipantID uuid.UUID `json:"destinationParticipantId"`
+	}{}
+	correlationID, err = call.client.Post(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants/%s/consult", call.ID, identifiable.GetID()),
+		struct {
+			SpeakTo     string            `json:"speakTo"`
+			Destination callTargetPayload `json:"destination"`
+		}{
+			SpeakTo:     speakTo,
+			Destination: destination.payload(),
+		},
+		&result,
+	)
+	return result.DestinationParticipantID, correlationID, err
+}
+
+// UpdateConsult changes who the agent speaks to during a consult transfer
+//
+// speakTo is one of ConsultSpeakToDestination, ConsultSpeakToObject, ConsultSpeakToBoth.
+func (call ConversationCall) UpdateConsult(context context.Context, identifiable Identifiable, speakTo string) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	if err = checkConsultSpeakTo(speakTo); err != nil {
+		return
+	}
+	return call.client.Patch(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants/%s/consult", call.ID, identifiable.GetID()),
+		struct {
+			SpeakTo string `json:"speakTo"`
+		}{SpeakTo: speakTo},
+		nil,
+	)
+}
+
+// CancelConsult cancels a consult transfer
+func (call ConversationCall) CancelConsult(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	return call.client.Delete(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants/%s/consult", call.ID, identifiable.GetID()),
+		nil,
+	)
+}
+
+// Hold holds a participant of this Call
+func (call ConversationCall) Hold(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	return call.patchParticipant(context, identifiable, struct {
+		Held bool `json:"held"`
+	}{Held: true})
+}
+
+// Unhold resumes a participant of this Call
+func (call ConversationCall) Unhold(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	return call.patchParticipant(context, identifiable, struct {
+		Held bool `json:"held"`
+	}{Held: false})
+}
+
+// Mute mutes or unmutes a participant of this Call
+func (call ConversationCall) Mute(context context.Context, identifiable Identifiable, muted bool) (correlationID string, err error) {
+	return call.patchParticipant(context, identifiable, struct {
+		Muted bool `json:"muted"`
+	}{Muted: muted})
+}
+
+// StartRecording starts or resumes the recording of this Call
+func (call ConversationCall) StartRecording(context context.Context) (correlationID string, err error) {
+	return call.setRecordingState(context, "ACTIVE")
+}
+
+// PauseRecording pauses the recording of this Call
+func (call ConversationCall) PauseRecording(context context.Context) (correlationID string, err error) {
+	return call.setRecordingState(context, "PAUSED")
+}
+
+// SendDigits sends DTMF digits to a participant of this Call
+//
+// The digits can be 0-9, *, #, A-D, and "," for a pause.
+func (call ConversationCall) SendDigits(context context.Context, identifiable Identifiable, digits string) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	if len(digits) == 0 {
+		return "", errors.ArgumentMissing.With("digits")
+	}
+	if index := strings.IndexFunc(digits, func(r rune) bool { return !strings.ContainsRune("0123456789*#ABCDabcd,", r) }); index >= 0 {
+		return "", errors.ArgumentInvalid.With("digits", digits)
+	}
+	return call.client.Post(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants/%s/digits", call.ID, identifiable.GetID()),
+		struct {
+			Digits string `json:"digits"`
+		}{Digits: digits},
+		nil,
+	)
+}
+
+// Conference adds the given targets to this Call
+func (call ConversationCall) Conference(context context.Context, targets ...CallTarget) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	if len(targets) == 0 {
+		return "", errors.ArgumentMissing.With("targets")
+	}
+	payload := struct {
+		Participants []callTargetPayload `json:"participants"`
+	}{}
+	for _, target := range targets {
+		payload.Participants = append(payload.Participants, target.payload())
+	}
+	return call.client.Post(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants", call.ID),
+		payload,
+		nil,
+	)
+}
+
+// UpdateAttributes updates the attributes of a participant of this Call
+//
+// The attributes are merged with the existing ones, attributes that are not given are left untouched.
+//
+// implements AttributesUpdater
+func (call ConversationCall) UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	return updateParticipantAttributes(call.logger.ToContext(context), call.client, NewURI("/conversations/calls/%s/participants/%s/attributes", call.ID, identifiable.GetID()), attributes)
+}
+
+// Wrapup wraps up a Participant of this Call
+func (call ConversationCall) Wrapup(context context.Context, identifiable Identifiable, wrapup *Wrapup) (correlationID string, err error) {
+	return call.patchParticipant(context, identifiable, MediaParticipantRequest{Wrapup: wrapup})
+}
+
+// patchParticipant sends a PATCH request about a participant of this Call
+func (call ConversationCall) patchParticipant(context context.Context, identifiable Identifiable, payload interface{}) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	return call.client.Patch(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s/participants/%s", call.ID, identifiable.GetID()),
+		payload,
+		nil,
+	)
+}
+
+// setRecordingState sets the recording state of this Call
+func (call ConversationCall) setRecordingState(context context.Context, state string) (correlationID string, err error) {
+	if err = call.checkInitialized(); err != nil {
+		return
+	}
+	return call.client.Patch(
+		call.logger.ToContext(context),
+		NewURI("/conversations/calls/%s", call.ID),
+		struct {
+			RecordingState string `json:"recordingState"`
+		}{RecordingState: state},
+		nil,
+	)
+}
+
+// checkInitialized checks if this Call can send requests
+func (call ConversationCall) checkInitialized() error {
+	return checkInitialized(call.client, "Call", call.ID)
+}
+
+// GetID gets the identifier of this
+//
+// This is the ID of the User or the Queue, uuid.Nil for an Address.
+//
+// implements Identifiable
+func (target CallTarget) GetID() uuid.UUID {
+	if target.User != nil {
+		return target.User.GetID()
+	}
+	if target.Queue != nil {
+		return target.Queue.GetID()
+	}
+	return uuid.Nil
+}
+
+// callTargetPayload is the JSON payload of a CallTarget in the consult and conference requests
+type callTargetPayload struct {
+	Address string `json:"address,omitempty"`
+	Name    string `json:"name,omitempty"`
+	UserID  string `json:"userId,omitempty"`
+	QueueID string `json:"queueId,omitempty"`
+}
+
+func (target CallTarget) payload() callTargetPayload {
+	return callTargetPayload{
+		Address: target.Address,
+		Name:    target.Name,
+		UserID:  identifiableID(target.User),
+		QueueID: identifiableID(target.Queue),
+	}
+}
+
+func checkConsultSpeakTo(speakTo string) error {
+	switch speakTo {
+	case ConsultSpeakToDestination, ConsultSpeakToObject, ConsultSpeakToBoth:
+		return nil
+	}
+	return errors.ArgumentInvalid.With("speakTo", speakTo, strings.Join([]string{ConsultSpeakToDestination, ConsultSpeakToObject, ConsultSpeakToBoth}, ", "))
+}
+
+// identifiableID gets the ID of an Identifiable as a string, empty if the Identifiable is nil
+func identifiableID(identifiable Identifiable) string {
+	if identifiable == nil || identifiable.GetID() == uuid.Nil {
+		return ""
+	}
+	return identifiable.GetID().String()
 }
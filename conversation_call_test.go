@@ -0,0 +1,196 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ConversationCallSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestConversationCallSuite(t *testing.T) {
+	suite.Run(t, new(ConversationCallSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ConversationCallSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ConversationCallSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ConversationCallSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ConversationCallSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ConversationCallSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *ConversationCallSuite) TestCanPlaceCall() {
+	conversationID := uuid.New()
+	queueID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/conversations/calls": map[string]any{"id": conversationID},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	call, _, err := client.PlaceCall(context.Background(), gcloudcx.OutboundCallRequest{
+		PhoneNumber: "+81312345678",
+		FromQueue:   gcloudcx.Queue{ID: queueID},
+		UUIData:     "1234",
+	})
+	suite.Require().NoErrorf(err, "Failed to place call. %s", err)
+	suite.Assert().Equal(conversationID, call.GetID())
+
+	var payload map[string]any
+	suite.Require().NoError(json.Unmarshal(server.LastRequest().Body, &payload))
+	suite.Assert().Equal(map[string]any{"phoneNumber": "+81312345678", "callFromQueueId": queueID.String(), "uuiData": "1234"}, payload)
+
+	_, _, err = client.PlaceCall(context.Background(), gcloudcx.OutboundCallRequest{})
+	suite.Assert().Error(err, "Placing a call without a destination should fail")
+}
+
+func (suite *ConversationCallSuite) TestCanControlCall() {
+	conversationID := uuid.New()
+	participant := gcloudcx.Participant{ID: uuid.New()}
+	userID := uuid.New()
+	destinationID := uuid.New()
+	participantPath := fmt.Sprintf("/api/v2/conversations/calls/%s/participants/%s", conversationID, participant.ID)
+	server := CreateRecordingTestServer(map[string]any{
+		"PATCH " + participantPath: struct{}{},
+		"PATCH " + fmt.Sprintf("/api/v2/conversations/calls/%s", conversationID):             struct{}{},
+		"POST " + participantPath + "/digits":                                                struct{}{},
+		"POST " + participantPath + "/replace":                                               struct{}{},
+		"POST " + participantPath + "/consult":                                               map[string]any{"destinationParticipantId": destinationID},
+		"PATCH " + participantPath + "/consult":                                              struct{}{},
+		"DELETE " + participantPath + "/consult":                                             struct{}{},
+		"POST " + fmt.Sprintf("/api/v2/conversations/calls/%s/participants", conversationID): struct{}{},
+	})
+	defer server.Close()
+	call := gcloudcx.New[gcloudcx.ConversationCall](context.Background(), CreateTestClient(server.URL, suite.Logger), conversationID, suite.Logger)
+	var _ gcloudcx.StateUpdater = call
+	var _ gcloudcx.Disconnecter = call
+	var _ gcloudcx.Transferrer = call
+
+	expect := func(method, path, body string) {
+		request := server.LastRequest()
+		suite.Assert().Equal(method, request.Method)
+		suite.Assert().Equal(path, request.Path)
+		if len(body) > 0 {
+			suite.Assert().JSONEq(body, string(request.Body))
+		}
+	}
+
+	_, err := call.Hold(context.Background(), participant)
+	suite.Require().NoErrorf(err, "Failed to hold. %s", err)
+	expect(http.MethodPatch, participantPath, `{"held": true}`)
+
+	_, err = call.Unhold(context.Background(), participant)
+	suite.Require().NoErrorf(err, "Failed to unhold. %s", err)
+	expect(http.MethodPatch, participantPath, `{"held": false}`)
+
+	_, err = call.Mute(context.Background(), participant, true)
+	suite.Require().NoErrorf(err, "Failed to mute. %s", err)
+	expect(http.MethodPatch, participantPath, `{"muted": true}`)
+
+	_, err = call.PauseRecording(context.Background())
+	suite.Require().NoErrorf(err, "Failed to pause the recording. %s", err)
+	expect(http.MethodPatch, fmt.Sprintf("/api/v2/conversations/calls/%s", conversationID), `{"recordingState": "PAUSED"}`)
+
+	_, err = call.SendDigits(context.Background(), participant, "1234#")
+	suite.Require().NoErrorf(err, "Failed to send digits. %s", err)
+	expect(http.MethodPost, participantPath+"/digits", `{"digits": "1234#"}`)
+
+	_, err = call.Transfer(context.Background(), participant, gcloudcx.User{ID: userID})
+	suite.Require().NoErrorf(err, "Failed to transfer. %s", err)
+	expect(http.MethodPost, participantPath+"/replace", fmt.Sprintf(`{"userId": "%s"}`, userID))
+
+	destination, _, err := call.Consult(context.Background(), participant, gcloudcx.CallTarget{Address: "+81398765432"}, gcloudcx.ConsultSpeakToDestination)
+	suite.Require().NoErrorf(err, "Failed to consult. %s", err)
+	suite.Assert().Equal(destinationID, destination)
+	expect(http.MethodPost, participantPath+"/consult", `{"speakTo": "DESTINATION", "destination": {"address": "+81398765432"}}`)
+
+	_, err = call.UpdateConsult(context.Background(), participant, gcloudcx.ConsultSpeakToBoth)
+	suite.Require().NoErrorf(err, "Failed to update the consult. %s", err)
+	expect(http.MethodPatch, participantPath+"/consult", `{"speakTo": "BOTH"}`)
+
+	_, err = call.CancelConsult(context.Background(), participant)
+	suite.Require().NoErrorf(err, "Failed to cancel the consult. %s", err)
+	expect(http.MethodDelete, participantPath+"/consult", "")
+
+	_, err = call.Conference(context.Background(), gcloudcx.CallTarget{Address: "+81398765432", Name: "Supervisor"})
+	suite.Require().NoErrorf(err, "Failed to conference. %s", err)
+	expect(http.MethodPost, fmt.Sprintf("/api/v2/conversations/calls/%s/participants", conversationID), `{"participants": [{"address": "+81398765432", "name": "Supervisor"}]}`)
+
+	_, err = call.Disconnect(context.Background(), participant)
+	suite.Require().NoErrorf(err, "Failed to disconnect. %s", err)
+	expect(http.MethodPatch, participantPath, `{"state": "disconnected"}`)
+}
+
+func (suite *ConversationCallSuite) TestShouldNotControlCallWithInvalidArguments() {
+	participant := gcloudcx.Participant{ID: uuid.New()}
+	_, err := gcloudcx.ConversationCall{ID: uuid.New()}.Hold(context.Background(), participant)
+	suite.Assert().Error(err, "An uninitialized call should not send requests")
+
+	call := gcloudcx.New[gcloudcx.ConversationCall](context.Background(), CreateTestClient("http://localhost", suite.Logger), uuid.New(), suite.Logger)
+	_, err = call.SendDigits(context.Background(), participant, "12x")
+	suite.Assert().Error(err, "Invalid digits should fail")
+	_, _, err = call.Consult(context.Background(), participant, gcloudcx.CallTarget{Address: "+81398765432"}, "EVERYONE")
+	suite.Assert().Error(err, "Invalid speakTo should fail")
+	_, err = call.Transfer(context.Background(), participant, gcloudcx.Participant{ID: uuid.New()})
+	suite.Assert().Error(err, "Transferring to a participant should fail")
+}
@@ -1,17 +1,22 @@
 package gcloudcx
 
 import (
+	"context"
 	"time"
 
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
 	"github.com/google/uuid"
 )
 
 // ConversationCallback describes a Callback (like belonging to Participant)
+//
+// When fetched from /api/v2/conversations/callbacks/{id}, the ID is the Conversation ID and the Participants are set
 type ConversationCallback struct {
-	ID        uuid.UUID `json:"id"`
-	State     string    `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
-	Direction string    `json:"direction"` // inbound,outbound
-	Held      bool      `json:"held"`
+	ID        uuid.UUID         `json:"id"`
+	State     ConversationState `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
+	Direction Direction         `json:"direction"` // inbound,outbound
+	Held      bool              `json:"held"`
 
 	ConnectedTime     time.Time `json:"connectedTime"`
 	DisconnectedTime  time.Time `json:"disconnectedTime"`
@@ -19,7 +24,7 @@ type ConversationCallback struct {
 	StartHoldTime     time.Time `json:"startHoldTime"`
 	ScheduledTime     time.Time `json:"callbackScheduledTime"`
 
-	DisconnectType string `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
+	DisconnectType DisconnectType `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
 
 	Segments                  []Segment      `json:"segments"`
 	Provider                  string         `json:"provider"`
@@ -30,4 +35,177 @@ type ConversationCallback struct {
 	CallbackUserName          string         `json:"callbackUserName"`
 	ScriptID                  string         `json:"scriptId"`
 	AutomatedCallbackConfigID string         `json:"automatedCallbackConfigId"`
+	Participants              []*Participant `json:"participants,omitempty"`
+	client                    *Client        `json:"-"`
+	logger                    *logger.Logger `json:"-"`
+}
+
+// CallbackIdentifier identifies a callback in a bulk request
+type CallbackIdentifier struct {
+	ConversationID uuid.UUID `json:"conversationId"`
+	CallbackID     uuid.UUID `json:"callbackId"` // the ID of the callback communication
+}
+
+// CreateCallback creates a scheduled callback
+//
+// The returned ConversationCallback only has its ID set, use Fetch to get the callback details.
+func (client *Client) CreateCallback(context context.Context, request *CallbackRequest) (callback *ConversationCallback, correlationID string, err error) {
+	if request == nil {
+		return nil, "", errors.ArgumentMissing.With("request")
+	}
+	if err = request.Validate(); err != nil {
+		return nil, "", err
+	}
+	result := struct {
+		Conversation EntityRef `json:"conversation"`
+	}{}
+	if correlationID, err = client.Post(context, NewURI("/conversations/callbacks"), request, &result); err != nil {
+		return nil, correlationID, err
+	}
+	callback = &ConversationCallback{}
+	callback.Initialize(result.Conversation.ID, client, client.Logger)
+	return callback, correlationID, nil
+}
+
+// DisconnectCallbacks disconnects several callbacks at once
+func (client *Client) DisconnectCallbacks(context context.Context, callbacks ...CallbackIdentifier) (correlationID string, err error) {
+	if len(callbacks) == 0 {
+		return "", errors.ArgumentMissing.With("callbacks")
+	}
+	return client.Post(
+		context,
+		NewURI("/conversations/callbacks/bulk/disconnect"),
+		struct {
+			Callbacks []CallbackIdentifier `json:"callbackDisconnectIdentifiers"`
+		}{Callbacks: callbacks},
+		nil,
+	)
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (callback *ConversationCallback) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			callback.ID = parameter
+		case *Client:
+			callback.client = parameter
+		case *logger.Logger:
+			callback.logger = parameter.Child("conversation", "conversation", "id", callback.ID, "media", "callback")
+		}
+	}
+	if callback.logger == nil {
+		callback.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (callback ConversationCallback) GetID() uuid.UUID {
+	return callback.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (callback ConversationCallback) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/conversations/callbacks/%s", ids[0])
+	}
+	if callback.ID != uuid.Nil {
+		return NewURI("/api/v2/conversations/callbacks/%s", callback.ID)
+	}
+	return URI("/api/v2/conversations/callbacks/")
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (callback ConversationCallback) String() string {
+	return callback.ID.String()
+}
+
+// Disconnect disconnect an Identifiable from this
+//
+// implements Disconnecter
+func (callback ConversationCallback) Disconnect(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	return callback.UpdateState(context, identifiable, "disconnected")
+}
+
+// UpdateState update the state of an identifiable in this
+//
+// Unknown states are rejected before the request is sent.
+//
+// implements StateUpdater
+func (callback ConversationCallback) UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error) {
+	if err = state.Validate(); err != nil {
+		return
+	}
+	if err = checkInitialized(callback.client, "Callback", callback.ID); err != nil {
+		return
+	}
+	return callback.client.Patch(
+		callback.logger.ToContext(context),
+		NewURI("/conversations/callbacks/%s/participants/%s", callback.ID, identifiable.GetID()),
+		MediaParticipantRequest{State: state},
+		nil,
+	)
+}
+
+// UpdateAttributes updates the attributes of a participant of this Callback
+//
+// The attributes are merged with the existing ones, attributes that are not given are left untouched.
+//
+// implements AttributesUpdater
+func (callback ConversationCallback) UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error) {
+	if err = checkInitialized(callback.client, "Callback", callback.ID); err != nil {
+		return
+	}
+	return updateParticipantAttributes(callback.logger.ToContext(context), callback.client, NewURI("/conversations/callbacks/%s/participants/%s/attributes", callback.ID, identifiable.GetID()), attributes)
+}
+
+// Wrapup wraps up a Participant of this Callback
+func (callback ConversationCallback) Wrapup(context context.Context, identifiable Identifiable, wrapup *Wrapup) (correlationID string, err error) {
+	if err = checkInitialized(callback.client, "Callback", callback.ID); err != nil {
+		return
+	}
+	return callback.client.Patch(
+		callback.logger.ToContext(context),
+		NewURI("/conversations/callbacks/%s/participants/%s", callback.ID, identifiable.GetID()),
+		MediaParticipantRequest{Wrapup: wrapup},
+		nil,
+	)
+}
+
+// Reschedule reschedules this Callback
+//
+// The scheduled time must be in the future. If queue or agent are not nil, the callback is also routed to them.
+func (callback ConversationCallback) Reschedule(context context.Context, scheduledTime time.Time, queue Identifiable, agent Identifiable) (correlationID string, err error) {
+	if err = checkInitialized(callback.client, "Callback", callback.ID); err != nil {
+		return
+	}
+	if !scheduledTime.After(time.Now()) {
+		return "", errors.ArgumentInvalid.With("callbackScheduledTime", scheduledTime, "must be in the future")
+	}
+	return callback.client.Patch(
+		callback.logger.ToContext(context),
+		NewURI("/conversations/callbacks"),
+		struct {
+			ConversationID string    `json:"conversationId"`
+			ScheduledTime  time.Time `json:"callbackScheduledTime"`
+			QueueID        string    `json:"queueId,omitempty"`
+			AgentID        string    `json:"agentId,omitempty"`
+		}{
+			ConversationID: callback.ID.String(),
+			ScheduledTime:  scheduledTime.UTC(),
+			QueueID:        identifiableID(queue),
+			AgentID:        identifiableID(agent),
+		},
+		nil,
+	)
 }
@@ -0,0 +1,164 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// CallbackRequest describes a request to create a scheduled callback
+//
+// Use NewCallbackRequest and the With methods to build it.
+//
+// See: https://developer.genesys.cloud/routing/conversations/conversations-apis#post-api-v2-conversations-callbacks
+type CallbackRequest struct {
+	Queue           Identifiable
+	Numbers         []string // E.164 phone numbers, e.g. +81312345678
+	UserName        string
+	Script          Identifiable
+	ScheduledTime   time.Time // when to call back, now if zero
+	Data            map[string]string
+	CallerID        string
+	CallerIDName    string
+	CountryCode     string
+	Priority        int
+	Language        Identifiable
+	Skills          []Identifiable
+	PreferredAgents []Identifiable
+}
+
+// e164Pattern matches phone numbers in the E.164 format
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// NewCallbackRequest creates a new CallbackRequest to the given queue with the given numbers
+func NewCallbackRequest(queue Identifiable, numbers ...string) *CallbackRequest {
+	return &CallbackRequest{
+		Queue:   queue,
+		Numbers: numbers,
+		Data:    map[string]string{},
+	}
+}
+
+// WithUserName sets the name of the customer to call back
+func (request *CallbackRequest) WithUserName(name string) *CallbackRequest {
+	request.UserName = name
+	return request
+}
+
+// WithScript sets the script to show to the agent
+func (request *CallbackRequest) WithScript(script Identifiable) *CallbackRequest {
+	request.Script = script
+	return request
+}
+
+// ScheduledAt sets when the callback should be placed
+func (request *CallbackRequest) ScheduledAt(scheduledTime time.Time) *CallbackRequest {
+	request.ScheduledTime = scheduledTime
+	return request
+}
+
+// WithData adds a key/value pair to the user data of the callback
+func (request *CallbackRequest) WithData(key, value string) *CallbackRequest {
+	if request.Data == nil {
+		request.Data = map[string]string{}
+	}
+	request.Data[key] = value
+	return request
+}
+
+// WithCallerID sets the caller ID to present to the customer
+func (request *CallbackRequest) WithCallerID(number, name string) *CallbackRequest {
+	request.CallerID = number
+	request.CallerIDName = name
+	return request
+}
+
+// WithRouting sets the routing data of the callback
+func (request *CallbackRequest) WithRouting(priority int, language Identifiable, skills ...Identifiable) *CallbackRequest {
+	request.Priority = priority
+	request.Language = language
+	request.Skills = skills
+	return request
+}
+
+// WithPreferredAgents sets the agents that should get the callback first
+func (request *CallbackRequest) WithPreferredAgents(agents ...Identifiable) *CallbackRequest {
+	request.PreferredAgents = agents
+	return request
+}
+
+// Validate validates the callback request
+func (request CallbackRequest) Validate() error {
+	var merr errors.MultiError
+	if request.Queue == nil {
+		merr.Append(errors.ArgumentMissing.With("queueId"))
+	}
+	if len(request.Numbers) == 0 {
+		merr.Append(errors.ArgumentMissing.With("callbackNumbers"))
+	}
+	for _, number := range request.Numbers {
+		if !e164Pattern.MatchString(number) {
+			merr.Append(errors.ArgumentInvalid.With("callbackNumbers", number, "E.164"))
+		}
+	}
+	if len(request.CallerID) > 0 && !e164Pattern.MatchString(request.CallerID) {
+		merr.Append(errors.ArgumentInvalid.With("callerId", request.CallerID, "E.164"))
+	}
+	if !request.ScheduledTime.IsZero() && !request.ScheduledTime.After(time.Now()) {
+		merr.Append(errors.ArgumentInvalid.With("callbackScheduledTime", request.ScheduledTime, "must be in the future"))
+	}
+	return merr.AsError()
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (request CallbackRequest) MarshalJSON() ([]byte, error) {
+	type routingData struct {
+		QueueID           string   `json:"queueId"`
+		LanguageID        string   `json:"languageId,omitempty"`
+		Priority          int      `json:"priority,omitempty"`
+		SkillIDs          []string `json:"skillIds,omitempty"`
+		PreferredAgentIDs []string `json:"preferredAgentIds,omitempty"`
+	}
+	payload := struct {
+		QueueID       string            `json:"queueId"`
+		RoutingData   routingData       `json:"routingData"`
+		Numbers       []string          `json:"callbackNumbers"`
+		UserName      string            `json:"callbackUserName,omitempty"`
+		ScriptID      string            `json:"scriptId,omitempty"`
+		ScheduledTime *time.Time        `json:"callbackScheduledTime,omitempty"`
+		Data          map[string]string `json:"data,omitempty"`
+		CallerID      string            `json:"callerId,omitempty"`
+		CallerIDName  string            `json:"callerIdName,omitempty"`
+		CountryCode   string            `json:"countryCode,omitempty"`
+	}{
+		QueueID: identifiableID(request.Queue),
+		RoutingData: routingData{
+			QueueID:    identifiableID(request.Queue),
+			LanguageID: identifiableID(request.Language),
+			Priority:   request.Priority,
+		},
+		Numbers:      request.Numbers,
+		UserName:     request.UserName,
+		ScriptID:     identifiableID(request.Script),
+		Data:         request.Data,
+		CallerID:     request.CallerID,
+		CallerIDName: request.CallerIDName,
+		CountryCode:  request.CountryCode,
+	}
+	for _, skill := range request.Skills {
+		payload.RoutingData.SkillIDs = append(payload.RoutingData.SkillIDs, identifiableID(skill))
+	}
+	for _, agent := range request.PreferredAgents {
+		payload.RoutingData.PreferredAgentIDs = append(payload.RoutingData.PreferredAgentIDs, identifiableID(agent))
+	}
+	if !request.ScheduledTime.IsZero() {
+		scheduledTime := request.ScheduledTime.UTC()
+		payload.ScheduledTime = &scheduledTime
+	}
+	data, err := json.Marshal(payload)
+	return data, errors.JSONMarshalError.Wrap(err)
+}
@@ -0,0 +1,149 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ConversationCallbackSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestConversationCallbackSuite(t *testing.T) {
+	suite.Run(t, new(ConversationCallbackSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ConversationCallbackSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ConversationCallbackSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ConversationCallbackSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ConversationCallbackSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ConversationCallbackSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *ConversationCallbackSuite) TestCanCreateCallback() {
+	conversationID := uuid.New()
+	queueID := uuid.New()
+	scheduledTime := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/conversations/callbacks": map[string]any{"conversation": map[string]any{"id": conversationID}},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	request := gcloudcx.NewCallbackRequest(gcloudcx.Queue{ID: queueID}, "+81312345678").
+		WithUserName("John Doe").
+		ScheduledAt(scheduledTime).
+		WithData("accountNumber", "12345")
+	callback, _, err := client.CreateCallback(context.Background(), request)
+	suite.Require().NoErrorf(err, "Failed to create callback. %s", err)
+	suite.Assert().Equal(conversationID, callback.GetID())
+	suite.Assert().JSONEq(fmt.Sprintf(`{
+		"queueId": "%[1]s",
+		"routingData": {"queueId": "%[1]s"},
+		"callbackNumbers": ["+81312345678"],
+		"callbackUserName": "John Doe",
+		"callbackScheduledTime": "%[2]s",
+		"data": {"accountNumber": "12345"}
+	}`, queueID, scheduledTime.Format(time.RFC3339)), string(server.LastRequest().Body))
+}
+
+func (suite *ConversationCallbackSuite) TestShouldNotCreateInvalidCallback() {
+	queue := gcloudcx.Queue{ID: uuid.New()}
+	suite.Assert().NoError(gcloudcx.NewCallbackRequest(queue, "+81312345678").Validate())
+	suite.Assert().Error(gcloudcx.NewCallbackRequest(nil, "+81312345678").Validate(), "A callback without a queue should fail")
+	suite.Assert().Error(gcloudcx.NewCallbackRequest(queue).Validate(), "A callback without numbers should fail")
+	suite.Assert().Error(gcloudcx.NewCallbackRequest(queue, "03-1234-5678").Validate(), "A callback with a non E.164 number should fail")
+	suite.Assert().Error(gcloudcx.NewCallbackRequest(queue, "+81312345678").WithCallerID("12345", "ACME").Validate(), "A callback with a non E.164 caller ID should fail")
+	suite.Assert().Error(gcloudcx.NewCallbackRequest(queue, "+81312345678").ScheduledAt(time.Now().Add(-time.Minute)).Validate(), "A callback in the past should fail")
+
+	_, _, err := CreateTestClient("http://localhost", suite.Logger).CreateCallback(context.Background(), gcloudcx.NewCallbackRequest(queue, "12345"))
+	suite.Assert().Error(err, "Creating an invalid callback should fail")
+}
+
+func (suite *ConversationCallbackSuite) TestCanManageCallbacks() {
+	conversationID := uuid.New()
+	participant := gcloudcx.Participant{ID: uuid.New()}
+	agentID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"PATCH /api/v2/conversations/callbacks": struct{}{},
+		"PATCH " + fmt.Sprintf("/api/v2/conversations/callbacks/%s/participants/%s", conversationID, participant.ID): struct{}{},
+		"POST /api/v2/conversations/callbacks/bulk/disconnect":                                                       struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	callback := gcloudcx.New[gcloudcx.ConversationCallback](context.Background(), client, conversationID, suite.Logger)
+
+	scheduledTime := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	_, err := callback.Reschedule(context.Background(), scheduledTime, nil, gcloudcx.User{ID: agentID})
+	suite.Require().NoErrorf(err, "Failed to reschedule. %s", err)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"conversationId": "%s", "callbackScheduledTime": "%s", "agentId": "%s"}`, conversationID, scheduledTime.Format(time.RFC3339), agentID), string(server.LastRequest().Body))
+
+	_, err = callback.Reschedule(context.Background(), time.Now().Add(-time.Hour), nil, nil)
+	suite.Assert().Error(err, "Rescheduling in the past should fail")
+
+	_, err = callback.Disconnect(context.Background(), participant)
+	suite.Require().NoErrorf(err, "Failed to disconnect. %s", err)
+	suite.Assert().JSONEq(`{"state": "disconnected"}`, string(server.LastRequest().Body))
+
+	callbackID := uuid.New()
+	_, err = client.DisconnectCallbacks(context.Background(), gcloudcx.CallbackIdentifier{ConversationID: conversationID, CallbackID: callbackID})
+	suite.Require().NoErrorf(err, "Failed to disconnect callbacks. %s", err)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"callbackDisconnectIdentifiers": [{"conversationId": "%s", "callbackId": "%s"}]}`, conversationID, callbackID), string(server.LastRequest().Body))
+}
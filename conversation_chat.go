@@ -14,27 +14,27 @@ import (
 
 // ConversationChat describes a Agent-side Chat
 type ConversationChat struct {
-	ID                uuid.UUID       `json:"id"`
-	SelfURI           URI             `json:"selfUri,omitempty"`
-	State             string          `json:"state"`          // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
-	Direction         string          `json:"direction"`      // inbound,outbound
-	DisconnectType    string          `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
-	Held              bool            `json:"held"`
-	ConnectedTime     time.Time       `json:"connectedTime"`
-	DisconnectedTime  time.Time       `json:"disconnectedTime"`
-	StartAlertingTime time.Time       `json:"startAlertingTime"`
-	StartHoldTime     time.Time       `json:"startHoldTime"`
-	Participants      []*Participant  `json:"participants"`
-	Segments          []Segment       `json:"segments"`
-	Provider          string          `json:"provider"`
-	PeerID            string          `json:"peerId"`
-	RoomID            string          `json:"roomId"`
-	ScriptID          string          `json:"scriptId"`
-	RecordingID       string          `json:"recordingId"`
-	AvatarImageURL    *url.URL        `json:"-"`
-	JourneyContext    *JourneyContext `json:"journeyContext"`
-	client            *Client         `json:"-"`
-	logger            *logger.Logger  `json:"-"`
+	ID                uuid.UUID         `json:"id"`
+	SelfURI           URI               `json:"selfUri,omitempty"`
+	State             ConversationState `json:"state"`          // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
+	Direction         Direction         `json:"direction"`      // inbound,outbound
+	DisconnectType    DisconnectType    `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
+	Held              bool              `json:"held"`
+	ConnectedTime     time.Time         `json:"connectedTime"`
+	DisconnectedTime  time.Time         `json:"disconnectedTime"`
+	StartAlertingTime time.Time         `json:"startAlertingTime"`
+	StartHoldTime     time.Time         `json:"startHoldTime"`
+	Participants      []*Participant    `json:"participants"`
+	Segments          []Segment         `json:"segments"`
+	Provider          string            `json:"provider"`
+	PeerID            string            `json:"peerId"`
+	RoomID            string            `json:"roomId"`
+	ScriptID          string            `json:"scriptId"`
+	RecordingID       string            `json:"recordingId"`
+	AvatarImageURL    *url.URL          `json:"-"`
+	JourneyContext    *JourneyContext   `json:"journeyContext"`
+	client            *Client           `json:"-"`
+	logger            *logger.Logger    `json:"-"`
 }
 
 // JourneyContext  describes a Journey Context
@@ -118,8 +118,13 @@ func (conversation ConversationChat) Disconnect(context context.Context, identif
 
 // UpdateState update the state of an identifiable in this
 //
+// Unknown states are rejected before the request is sent.
+//
 // implements StateUpdater
-func (conversation ConversationChat) UpdateState(context context.Context, identifiable Identifiable, state string) (correlationID string, err error) {
+func (conversation ConversationChat) UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error) {
+	if err = state.Validate(); err != nil {
+		return
+	}
 	return conversation.client.Patch(
 		conversation.logger.ToContext(context),
 		NewURI("/conversations/chats/%s/participants/%s", conversation.ID, identifiable.GetID()),
@@ -168,6 +173,15 @@ func (conversation ConversationChat) SetTyping(context context.Context, member I
 	)
 }
 
+// UpdateAttributes updates the attributes of a participant of this Chat
+//
+// The attributes are merged with the existing ones, attributes that are not given are left untouched.
+//
+// implements AttributesUpdater
+func (conversation ConversationChat) UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error) {
+	return updateParticipantAttributes(conversation.logger.ToContext(context), conversation.client, NewURI("/conversations/chats/%s/participants/%s/attributes", conversation.ID, identifiable.GetID()), attributes)
+}
+
 // Wrapup wraps up a Participant of this Conversation
 func (conversation ConversationChat) Wrapup(context context.Context, identifiable Identifiable, wrapup *Wrapup) (correlationID string, err error) {
 	return conversation.client.Patch(
@@ -1,34 +1,41 @@
 package gcloudcx
 
 import (
+	"context"
 	"time"
 
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/gildas/go-request"
 	"github.com/google/uuid"
 )
 
 // ConversationEmail describes an Email (like belonging to Participant)
 type ConversationEmail struct {
-	ID                uuid.UUID     `json:"id"`
-	State             string        `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
-	Direction         string        `json:"direction"` // inbound,outbound
-	Held              bool          `json:"held"`
-	ConnectedTime     time.Time     `json:"connectedTime"`
-	DisconnectedTime  time.Time     `json:"disconnectedTime"`
-	StartAlertingTime time.Time     `json:"startAlertingTime"`
-	StartHoldTime     time.Time     `json:"startHoldTime"`
-	Segments          []Segment     `json:"segments"`
-	Provider          string        `json:"provider"`
-	ScriptID          string        `json:"scriptId"`
-	PeerID            string        `json:"peerId"`
-	RecordingID       string        `json:"recordingId"`
-	AutoGenerated     bool          `json:"autoGenerated"`
-	Subject           string        `json:"subject"`
-	MessagesSent      int           `json:"messagesSent"`
-	MessageID         string        `json:"messageId"`
-	Spam              bool          `json:"spam"`
-	DraftAttachments  []*Attachment `json:"draftAttachments"`
-	DisconnectType    string        `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
-	ErrorInfo         ErrorBody     `json:"errorInfo"`
+	ID                uuid.UUID         `json:"id"`
+	State             ConversationState `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
+	Direction         Direction         `json:"direction"` // inbound,outbound
+	Held              bool              `json:"held"`
+	ConnectedTime     time.Time         `json:"connectedTime"`
+	DisconnectedTime  time.Time         `json:"disconnectedTime"`
+	StartAlertingTime time.Time         `json:"startAlertingTime"`
+	StartHoldTime     time.Time         `json:"startHoldTime"`
+	Participants      []*Participant    `json:"participants,omitempty"`
+	Segments          []Segment         `json:"segments"`
+	Provider          string            `json:"provider"`
+	ScriptID          string            `json:"scriptId"`
+	PeerID            string            `json:"peerId"`
+	RecordingID       string            `json:"recordingId"`
+	AutoGenerated     bool              `json:"autoGenerated"`
+	Subject           string            `json:"subject"`
+	MessagesSent      int               `json:"messagesSent"`
+	MessageID         string            `json:"messageId"`
+	Spam              bool              `json:"spam"`
+	DraftAttachments  []*Attachment     `json:"draftAttachments"`
+	DisconnectType    DisconnectType    `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
+	ErrorInfo         ErrorBody         `json:"errorInfo"`
+	client            *Client           `json:"-"`
+	logger            *logger.Logger    `json:"-"`
 }
 
 // Attachment describes an Email Attachment
@@ -40,3 +47,292 @@ type Attachment struct {
 	ContentLength int64  `json:"contentLength"`
 	InlineImage   bool   `json:"inlineImage"`
 }
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (email *ConversationEmail) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			email.ID = parameter
+		case *Client:
+			email.client = parameter
+		case *logger.Logger:
+			email.logger = parameter.Child("conversation", "conversation", "id", email.ID, "media", "email")
+		}
+	}
+	if email.logger == nil {
+		email.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (email ConversationEmail) GetID() uuid.UUID {
+	return email.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (email ConversationEmail) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/conversations/emails/%s", ids[0])
+	}
+	if email.ID != uuid.Nil {
+		return NewURI("/api/v2/conversations/emails/%s", email.ID)
+	}
+	return URI("/api/v2/conversations/emails/")
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (email ConversationEmail) String() string {
+	if len(email.Subject) > 0 {
+		return email.Subject
+	}
+	return email.ID.String()
+}
+
+// Disconnect disconnect an Identifiable from this
+//
+// implements Disconnecter
+func (email ConversationEmail) Disconnect(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	return email.UpdateState(context, identifiable, "disconnected")
+}
+
+// UpdateState update the state of an identifiable in this
+//
+// Unknown states are rejected before the request is sent.
+//
+// implements StateUpdater
+func (email ConversationEmail) UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error) {
+	if err = state.Validate(); err != nil {
+		return
+	}
+	return email.patchParticipant(context, identifiable, MediaParticipantRequest{State: state})
+}
+
+// Transfer transfers a participant of this Email to the given Queue
+//
+// implement Transferrer
+func (email ConversationEmail) Transfer(context context.Context, identifiable Identifiable, queue Identifiable) (correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	return email.client.Post(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/participants/%s/replace", email.ID, identifiable.GetID()),
+		struct {
+			ID string `json:"queueId"`
+		}{ID: queue.GetID().String()},
+		nil,
+	)
+}
+
+// UpdateAttributes updates the attributes of a participant of this Email
+//
+// The attributes are merged with the existing ones, attributes that are not given are left untouched.
+//
+// implements AttributesUpdater
+func (email ConversationEmail) UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	return updateParticipantAttributes(email.logger.ToContext(context), email.client, NewURI("/conversations/emails/%s/participants/%s/attributes", email.ID, identifiable.GetID()), attributes)
+}
+
+// Wrapup wraps up a Participant of this Email
+func (email ConversationEmail) Wrapup(context context.Context, identifiable Identifiable, wrapup *Wrapup) (correlationID string, err error) {
+	return email.patchParticipant(context, identifiable, MediaParticipantRequest{Wrapup: wrapup})
+}
+
+// GetMessages gets the messages of this Email
+//
+// The messages do not contain their bodies, use GetMessage to get them
+func (email ConversationEmail) GetMessages(context context.Context) (messages []*EmailMessage, correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	entities := struct {
+		Entities []*EmailMessage `json:"entities"`
+	}{}
+	correlationID, err = email.client.Get(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/messages", email.ID),
+		&entities,
+	)
+	return entities.Entities, correlationID, err
+}
+
+// GetMessage gets a message of this Email with its text and HTML bodies
+func (email ConversationEmail) GetMessage(context context.Context, messageID string) (message *EmailMessage, correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	if len(messageID) == 0 {
+		return nil, "", errors.ArgumentMissing.With("messageId")
+	}
+	message = &EmailMessage{}
+	correlationID, err = email.client.Get(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/messages/%s", email.ID, messageID),
+		message,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return message, correlationID, nil
+}
+
+// Send sends a message in this Email
+//
+// To reply to the customer, the message should be sent to the From address of the last received message.
+func (email ConversationEmail) Send(context context.Context, message EmailMessage) (sent *EmailMessage, correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	if len(message.To) == 0 {
+		return nil, "", errors.ArgumentMissing.With("to")
+	}
+	if len(message.TextBody) == 0 && len(message.HTMLBody) == 0 {
+		return nil, "", errors.ArgumentMissing.With("textBody")
+	}
+	sent = &EmailMessage{}
+	correlationID, err = email.client.Post(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/messages", email.ID),
+		message,
+		sent,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return sent, correlationID, nil
+}
+
+// Reply replies to the given message of this Email
+//
+// The reply is sent to the ReplyTo address of the message (or its From address) with the message's subject.
+// The From address of the reply is the To address of the message.
+func (email ConversationEmail) Reply(context context.Context, message EmailMessage, textBody, htmlBody string) (*EmailMessage, string, error) {
+	reply := EmailMessage{
+		To:       []EmailAddress{message.From},
+		Subject:  message.Subject,
+		TextBody: textBody,
+		HTMLBody: htmlBody,
+	}
+	if message.ReplyTo != nil && len(message.ReplyTo.Email) > 0 {
+		reply.To = []EmailAddress{*message.ReplyTo}
+	}
+	if len(message.To) > 0 {
+		reply.From = message.To[0]
+	}
+	return email.Send(context, reply)
+}
+
+// GetDraft gets the draft reply of this Email
+func (email ConversationEmail) GetDraft(context context.Context) (draft *EmailMessage, correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	draft = &EmailMessage{}
+	correlationID, err = email.client.Get(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/messages/draft", email.ID),
+		draft,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return draft, correlationID, nil
+}
+
+// UpdateDraft updates the draft reply of this Email
+func (email ConversationEmail) UpdateDraft(context context.Context, draft EmailMessage) (updated *EmailMessage, correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	updated = &EmailMessage{}
+	correlationID, err = email.client.Put(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/messages/draft", email.ID),
+		draft,
+		updated,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return updated, correlationID, nil
+}
+
+// UploadAttachment uploads an attachment to the draft of this Email
+//
+// The content is sent to the pre-signed URL given by Genesys Cloud,
+// the attachment appears in the draft's attachments once Genesys Cloud has processed it.
+func (email ConversationEmail) UploadAttachment(context context.Context, name string, content *request.Content, inline bool) (upload *UploadURL, correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	if len(name) == 0 {
+		return nil, "", errors.ArgumentMissing.With("fileName")
+	}
+	if content == nil {
+		return nil, "", errors.ArgumentMissing.With("content")
+	}
+	upload = &UploadURL{}
+	correlationID, err = email.client.Post(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/attachments/uploads", email.ID),
+		struct {
+			FileName    string `json:"fileName"`
+			InlineImage bool   `json:"inlineImage"`
+		}{FileName: name, InlineImage: inline},
+		upload,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	if err = email.client.Upload(email.logger.ToContext(context), *upload, content); err != nil {
+		return nil, correlationID, err
+	}
+	return upload, correlationID, nil
+}
+
+// RemoveDraftAttachment removes an attachment from the draft of this Email
+func (email ConversationEmail) RemoveDraftAttachment(context context.Context, attachmentID string) (correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	if len(attachmentID) == 0 {
+		return "", errors.ArgumentMissing.With("attachmentId")
+	}
+	return email.client.Delete(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/messages/draft/attachments/%s", email.ID, attachmentID),
+		nil,
+	)
+}
+
+// patchParticipant sends a PATCH request about a participant of this Email
+func (email ConversationEmail) patchParticipant(context context.Context, identifiable Identifiable, payload interface{}) (correlationID string, err error) {
+	if err = email.checkInitialized(); err != nil {
+		return
+	}
+	return email.client.Patch(
+		email.logger.ToContext(context),
+		NewURI("/conversations/emails/%s/participants/%s", email.ID, identifiable.GetID()),
+		payload,
+		nil,
+	)
+}
+
+// checkInitialized checks if this Email can send requests
+func (email ConversationEmail) checkInitialized() error {
+	return checkInitialized(email.client, "Email", email.ID)
+}
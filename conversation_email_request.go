@@ -0,0 +1,185 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// OutboundEmailRequest describes a request to create an outbound Email conversation
+//
+// Use NewOutboundEmailRequest and the With methods to build it.
+//
+// See: https://developer.genesys.cloud/commdigital/digital/email/#post-api-v2-conversations-emails
+type OutboundEmailRequest struct {
+	Queue             Identifiable
+	To                EmailAddress
+	From              EmailAddress
+	Subject           string
+	TextBody          string
+	HTMLBody          string
+	Attributes        map[string]string
+	ExternalContactID string
+}
+
+// NewOutboundEmailRequest creates a new OutboundEmailRequest from the given queue to the given address
+func NewOutboundEmailRequest(queue Identifiable, to EmailAddress, subject string) *OutboundEmailRequest {
+	return &OutboundEmailRequest{
+		Queue:      queue,
+		To:         to,
+		Subject:    subject,
+		Attributes: map[string]string{},
+	}
+}
+
+// WithFrom sets the address the Email is sent from
+func (request *OutboundEmailRequest) WithFrom(from EmailAddress) *OutboundEmailRequest {
+	request.From = from
+	return request
+}
+
+// WithBody sets the text and HTML bodies of the Email
+func (request *OutboundEmailRequest) WithBody(textBody, htmlBody string) *OutboundEmailRequest {
+	request.TextBody = textBody
+	request.HTMLBody = htmlBody
+	return request
+}
+
+// WithAttribute adds a participant attribute to the Email
+func (request *OutboundEmailRequest) WithAttribute(key, value string) *OutboundEmailRequest {
+	if request.Attributes == nil {
+		request.Attributes = map[string]string{}
+	}
+	request.Attributes[key] = value
+	return request
+}
+
+// WithExternalContact sets the external contact of the Email
+func (request *OutboundEmailRequest) WithExternalContact(contact Identifiable) *OutboundEmailRequest {
+	request.ExternalContactID = identifiableID(contact)
+	return request
+}
+
+// ApplyQueueSettings applies the email settings of the given queue to this request
+//
+// The From address is set from the queue's outbound email route (if not already set),
+// and the signature of that route is appended to the bodies if it is enabled.
+func (request *OutboundEmailRequest) ApplyQueueSettings(context context.Context, client *Client, queue *Queue, substitutions map[string]string) (correlationID string, err error) {
+	if queue == nil {
+		return "", errors.ArgumentMissing.With("queue")
+	}
+	if queue.OutboundEmailAddress == nil {
+		return "", errors.ArgumentMissing.With("outboundEmailAddress")
+	}
+	route, correlationID, err := queue.OutboundEmailAddress.FetchRoute(context, client)
+	if err != nil {
+		return correlationID, err
+	}
+	if request.Queue == nil {
+		request.Queue = queue
+	}
+	if len(request.From.Email) == 0 {
+		request.From = queue.OutboundEmailAddress.GetEmailAddress(route)
+	}
+	if !route.Signature.Enabled || route.Signature.CannedResponseID == uuid.Nil {
+		return correlationID, nil
+	}
+	signature, correlationID, err := Fetch[ResponseManagementResponse](context, client, route.Signature.CannedResponseID)
+	if err != nil {
+		return correlationID, err
+	}
+	if text, err := signature.ApplySubstitutions(context, "text/plain", substitutions); err == nil {
+		request.TextBody = appendSignature(request.TextBody, text, "\n\n")
+	}
+	if html, err := signature.ApplySubstitutions(context, "text/html", substitutions); err == nil && len(request.HTMLBody) > 0 {
+		request.HTMLBody = appendSignature(request.HTMLBody, html, "<br/>")
+	}
+	return correlationID, nil
+}
+
+// Validate validates the outbound email request
+func (request OutboundEmailRequest) Validate() error {
+	var merr errors.MultiError
+	if request.Queue == nil {
+		merr.Append(errors.ArgumentMissing.With("queueId"))
+	}
+	if len(request.To.Email) == 0 {
+		merr.Append(errors.ArgumentMissing.With("toAddress"))
+	} else if !strings.Contains(request.To.Email, "@") {
+		merr.Append(errors.ArgumentInvalid.With("toAddress", request.To.Email))
+	}
+	if len(request.From.Email) == 0 {
+		merr.Append(errors.ArgumentMissing.With("fromAddress"))
+	}
+	if len(request.TextBody) == 0 && len(request.HTMLBody) == 0 {
+		merr.Append(errors.ArgumentMissing.With("textBody"))
+	}
+	return merr.AsError()
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (request OutboundEmailRequest) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Direction         string            `json:"direction"`
+		QueueID           string            `json:"queueId"`
+		ToAddress         string            `json:"toAddress"`
+		ToName            string            `json:"toName,omitempty"`
+		FromAddress       string            `json:"fromAddress"`
+		FromName          string            `json:"fromName,omitempty"`
+		Subject           string            `json:"subject,omitempty"`
+		TextBody          string            `json:"textBody,omitempty"`
+		HTMLBody          string            `json:"htmlBody,omitempty"`
+		Attributes        map[string]string `json:"attributes,omitempty"`
+		ExternalContactID string            `json:"externalContactId,omitempty"`
+	}{
+		Direction:         "OUTBOUND",
+		QueueID:           identifiableID(request.Queue),
+		ToAddress:         request.To.Email,
+		ToName:            request.To.Name,
+		FromAddress:       request.From.Email,
+		FromName:          request.From.Name,
+		Subject:           request.Subject,
+		TextBody:          request.TextBody,
+		HTMLBody:          request.HTMLBody,
+		Attributes:        request.Attributes,
+		ExternalContactID: request.ExternalContactID,
+	})
+	return data, errors.JSONMarshalError.Wrap(err)
+}
+
+// CreateOutboundEmail creates an outbound Email conversation
+//
+// The returned ConversationEmail only has its ID set, use Fetch to get the email details.
+func (client *Client) CreateOutboundEmail(context context.Context, request *OutboundEmailRequest) (email *ConversationEmail, correlationID string, err error) {
+	if request == nil {
+		return nil, "", errors.ArgumentMissing.With("request")
+	}
+	if err = request.Validate(); err != nil {
+		return nil, "", err
+	}
+	result := struct {
+		ID uuid.UUID `json:"id"`
+	}{}
+	if correlationID, err = client.Post(context, NewURI("/conversations/emails"), request, &result); err != nil {
+		return nil, correlationID, err
+	}
+	email = &ConversationEmail{}
+	email.Initialize(result.ID, client, client.Logger)
+	return email, correlationID, nil
+}
+
+// appendSignature appends a signature to a body
+func appendSignature(body, signature, separator string) string {
+	if len(signature) == 0 {
+		return body
+	}
+	if len(body) == 0 {
+		return signature
+	}
+	return body + separator + signature
+}
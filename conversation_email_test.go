@@ -0,0 +1,203 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/gildas/go-request"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ConversationEmailSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestConversationEmailSuite(t *testing.T) {
+	suite.Run(t, new(ConversationEmailSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ConversationEmailSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ConversationEmailSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ConversationEmailSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ConversationEmailSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ConversationEmailSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+func (suite *ConversationEmailSuite) TestCanCreateOutboundEmail() {
+	conversationID := uuid.New()
+	queueID := uuid.New()
+	routeID := uuid.New()
+	signatureID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET /api/v2/routing/email/domains/acme.mypurecloud.com/routes/" + routeID.String(): map[string]any{
+			"id":        routeID,
+			"pattern":   "support",
+			"fromName":  "ACME Support",
+			"signature": map[string]any{"enabled": true, "cannedResponseId": signatureID, "inclusionType": "Send"},
+		},
+		"GET /api/v2/responsemanagement/responses/" + signatureID.String(): map[string]any{
+			"id": signatureID,
+			"texts": []map[string]any{
+				{"contentType": "text/plain", "content": "-- {{agent}}"},
+				{"contentType": "text/html", "content": "<p>{{agent}}</p>"},
+			},
+		},
+		"POST /api/v2/conversations/emails": map[string]any{"id": conversationID},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	queue := &gcloudcx.Queue{
+		ID: queueID,
+		OutboundEmailAddress: &gcloudcx.QueueEmailAddress{
+			Domain: gcloudcx.EmailDomainRef{ID: "acme.mypurecloud.com"},
+			Route:  &gcloudcx.EmailInboundRoute{ID: routeID},
+		},
+	}
+
+	request := gcloudcx.NewOutboundEmailRequest(nil, gcloudcx.EmailAddress{Name: "John Doe", Email: "john@example.com"}, "Your order").
+		WithBody("Hello", "<p>Hello</p>")
+	_, err := request.ApplyQueueSettings(context.Background(), client, queue, map[string]string{"agent": "Jane"})
+	suite.Require().NoErrorf(err, "Failed to apply queue settings. %s", err)
+	suite.Assert().Equal("support@acme.mypurecloud.com", request.From.Email)
+	suite.Assert().Equal("Hello\n\n-- Jane", request.TextBody)
+
+	email, _, err := client.CreateOutboundEmail(context.Background(), request)
+	suite.Require().NoErrorf(err, "Failed to create email. %s", err)
+	suite.Assert().Equal(conversationID, email.GetID())
+	suite.Assert().JSONEq(fmt.Sprintf(`{
+		"direction": "OUTBOUND",
+		"queueId": "%s",
+		"toAddress": "john@example.com",
+		"toName": "John Doe",
+		"fromAddress": "support@acme.mypurecloud.com",
+		"fromName": "ACME Support",
+		"subject": "Your order",
+		"textBody": "Hello\n\n-- Jane",
+		"htmlBody": "<p>Hello</p><br/><p>Jane</p>"
+	}`, queueID), string(server.LastRequest().Body))
+
+	_, _, err = client.CreateOutboundEmail(context.Background(), gcloudcx.NewOutboundEmailRequest(queue, gcloudcx.EmailAddress{Email: "john"}, "Oops"))
+	suite.Assert().Error(err, "Creating an invalid email should fail")
+}
+
+func (suite *ConversationEmailSuite) TestCanReplyToEmail() {
+	conversationID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/conversations/emails/%s/messages", conversationID): map[string]any{
+			"entities": []map[string]any{{"id": "message-1", "subject": "Help"}},
+		},
+		fmt.Sprintf("GET /api/v2/conversations/emails/%s/messages/message-1", conversationID): map[string]any{
+			"id":       "message-1",
+			"to":       []map[string]any{{"email": "support@acme.com"}},
+			"from":     map[string]any{"name": "John Doe", "email": "john@example.com"},
+			"subject":  "Help",
+			"textBody": "I need help",
+		},
+		fmt.Sprintf("POST /api/v2/conversations/emails/%s/messages", conversationID): map[string]any{"id": "message-2"},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	email := gcloudcx.New[gcloudcx.ConversationEmail](context.Background(), client, conversationID, suite.Logger)
+
+	messages, _, err := email.GetMessages(context.Background())
+	suite.Require().NoErrorf(err, "Failed to get messages. %s", err)
+	suite.Require().Len(messages, 1)
+
+	message, _, err := email.GetMessage(context.Background(), messages[0].ID)
+	suite.Require().NoErrorf(err, "Failed to get message. %s", err)
+	suite.Assert().Equal("I need help", message.TextBody)
+
+	reply, _, err := email.Reply(context.Background(), *message, "Sure", "<p>Sure</p>")
+	suite.Require().NoErrorf(err, "Failed to reply. %s", err)
+	suite.Assert().Equal("message-2", reply.ID)
+	suite.Assert().JSONEq(`{
+		"to": [{"name": "John Doe", "email": "john@example.com"}],
+		"from": {"name": "", "email": "support@acme.com"},
+		"subject": "Help",
+		"textBody": "Sure",
+		"htmlBody": "<p>Sure</p>"
+	}`, string(server.LastRequest().Body))
+
+	_, _, err = email.Reply(context.Background(), *message, "", "")
+	suite.Assert().Error(err, "Replying without a body should fail")
+}
+
+func (suite *ConversationEmailSuite) TestCanUploadAttachment() {
+	conversationID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"PUT /uploads/attachment": struct{}{},
+	})
+	defer server.Close()
+	server.Responses[fmt.Sprintf("POST /api/v2/conversations/emails/%s/attachments/uploads", conversationID)] = map[string]any{
+		"url":       server.URL + "/uploads/attachment",
+		"uploadKey": "key-1",
+		"headers":   map[string]string{"x-amz-meta-key": "value"},
+	}
+	server.Responses[fmt.Sprintf("DELETE /api/v2/conversations/emails/%s/messages/draft/attachments/attachment-1", conversationID)] = struct{}{}
+	client := CreateTestClient(server.URL, suite.Logger)
+	email := gcloudcx.New[gcloudcx.ConversationEmail](context.Background(), client, conversationID, suite.Logger)
+
+	upload, _, err := email.UploadAttachment(context.Background(), "invoice.txt", request.ContentWithData([]byte("Hello"), "text/plain"), false)
+	suite.Require().NoErrorf(err, "Failed to upload attachment. %s", err)
+	suite.Assert().Equal("key-1", upload.UploadKey)
+	suite.Assert().Equal("Hello", string(server.LastRequest().Body))
+
+	_, err = email.RemoveDraftAttachment(context.Background(), "attachment-1")
+	suite.Require().NoErrorf(err, "Failed to remove attachment. %s", err)
+	suite.Assert().Equal(http.MethodDelete, server.LastRequest().Method)
+}
@@ -1,18 +1,23 @@
 package gcloudcx
 
 import (
+	"context"
+	"sort"
 	"time"
 
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/gildas/go-request"
 	"github.com/google/uuid"
 )
 
 // ConversationMessage describes a Message (like belonging to Participant)
 type ConversationMessage struct {
-	ID        uuid.UUID `json:"id"`
-	Type      string    `json:"type"`
-	Direction string    `json:"direction"` // inbound,outbound
-	State     string    `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
-	Held      bool      `json:"held"`
+	ID        uuid.UUID         `json:"id"`
+	Type      string            `json:"type"`
+	Direction Direction         `json:"direction"` // inbound,outbound
+	State     ConversationState `json:"state"`     // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
+	Held      bool              `json:"held"`
 
 	RecordingID string `json:"recordingId,omitempty"`
 
@@ -29,11 +34,314 @@ type ConversationMessage struct {
 	StartAlertingTime time.Time `json:"startAlertingTime"`
 	StartHoldTime     time.Time `json:"startHoldTime"`
 
-	Messages []MessageDetails `json:"messages"`
+	Participants []*Participant   `json:"participants,omitempty"`
+	Messages     []MessageDetails `json:"messages"`
 
-	DisconnectType string    `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
-	ErrorInfo      ErrorBody `json:"errorInfo"`
+	DisconnectType DisconnectType `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
+	ErrorInfo      ErrorBody      `json:"errorInfo"`
 	// Screenshares []ScreenShare `json:"screenshares"`
 	// SocialExpressions []SocialExpression `json:"socialExpressions"`
 	// Videos []Video `json:"videos"`
+	client *Client        `json:"-"`
+	logger *logger.Logger `json:"-"`
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (conversation *ConversationMessage) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			conversation.ID = parameter
+		case *Client:
+			conversation.client = parameter
+		case *logger.Logger:
+			conversation.logger = parameter.Child("conversation", "conversation", "id", conversation.ID, "media", "message")
+		}
+	}
+	if conversation.logger == nil {
+		conversation.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (conversation ConversationMessage) GetID() uuid.UUID {
+	return conversation.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (conversation ConversationMessage) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/conversations/messages/%s", ids[0])
+	}
+	if conversation.ID != uuid.Nil {
+		return NewURI("/api/v2/conversations/messages/%s", conversation.ID)
+	}
+	return URI("/api/v2/conversations/messages/")
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (conversation ConversationMessage) String() string {
+	return conversation.ID.String()
+}
+
+// Disconnect disconnect an Identifiable from this
+//
+// implements Disconnecter
+func (conversation ConversationMessage) Disconnect(context context.Context, identifiable Identifiable) (correlationID string, err error) {
+	return conversation.UpdateState(context, identifiable, "disconnected")
+}
+
+// UpdateState update the state of an identifiable in this
+//
+// Unknown states are rejected before the request is sent.
+//
+// implements StateUpdater
+func (conversation ConversationMessage) UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error) {
+	if err = state.Validate(); err != nil {
+		return
+	}
+	return conversation.patchParticipant(context, identifiable, MediaParticipantRequest{State: state})
+}
+
+// Transfer transfers a participant of this Conversation to the given Queue
+//
+// implement Transferrer
+func (conversation ConversationMessage) Transfer(context context.Context, identifiable Identifiable, queue Identifiable) (correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	return conversation.client.Post(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/participants/%s/replace", conversation.ID, identifiable.GetID()),
+		struct {
+			ID string `json:"queueId"`
+		}{ID: queue.GetID().String()},
+		nil,
+	)
+}
+
+// UpdateAttributes updates the attributes of a participant of this Conversation
+//
+// The attributes are merged with the existing ones, attributes that are not given are left untouched.
+//
+// implements AttributesUpdater
+func (conversation ConversationMessage) UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	return updateParticipantAttributes(conversation.logger.ToContext(context), conversation.client, NewURI("/conversations/messages/%s/participants/%s/attributes", conversation.ID, identifiable.GetID()), attributes)
+}
+
+// Wrapup wraps up a Participant of this Conversation
+func (conversation ConversationMessage) Wrapup(context context.Context, identifiable Identifiable, wrapup *Wrapup) (correlationID string, err error) {
+	return conversation.patchParticipant(context, identifiable, MediaParticipantRequest{Wrapup: wrapup})
+}
+
+// SendText sends a text message from the given communication
+//
+// The communication is the message media of the agent's participant.
+func (conversation ConversationMessage) SendText(context context.Context, communication Identifiable, text string, mediaIDs ...string) (*MessageData, string, error) {
+	return conversation.Send(context, communication, NormalizedMessage{Type: NormalizedMessageTypeText, Text: text}, mediaIDs...)
+}
+
+// SendContent sends a structured message from the given communication
+//
+// The communication is the message media of the agent's participant.
+func (conversation ConversationMessage) SendContent(context context.Context, communication Identifiable, content ...NormalizedMessageContent) (*MessageData, string, error) {
+	return conversation.Send(context, communication, NormalizedMessage{Type: NormalizedMessageTypeStructured, Content: content})
+}
+
+// Send sends a message from the given communication
+//
+// The media must have been uploaded with UploadMedia first.
+func (conversation ConversationMessage) Send(context context.Context, communication Identifiable, message NormalizedMessage, mediaIDs ...string) (sent *MessageData, correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	if communication == nil {
+		return nil, "", errors.ArgumentMissing.With("communication")
+	}
+	if len(mediaIDs) == 0 {
+		if err = message.Validate(); err != nil {
+			return nil, "", err
+		}
+	}
+	payload := struct {
+		TextBody             string                     `json:"textBody,omitempty"`
+		MediaIDs             []string                   `json:"mediaIds,omitempty"`
+		UseNormalizedMessage bool                       `json:"useNormalizedMessage,omitempty"`
+		NormalizedContent    []NormalizedMessageContent `json:"normalizedContent,omitempty"`
+	}{
+		TextBody: message.Text,
+		MediaIDs: mediaIDs,
+	}
+	if message.Type == NormalizedMessageTypeStructured {
+		payload.UseNormalizedMessage = true
+		payload.NormalizedContent = message.Content
+	}
+	sent = &MessageData{}
+	correlationID, err = conversation.client.Post(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/communications/%s/messages", conversation.ID, communication.GetID()),
+		payload,
+		sent,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return sent, correlationID, nil
+}
+
+// SetTyping sends a typing indicator from the given communication
+func (conversation ConversationMessage) SetTyping(context context.Context, communication Identifiable) (correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	if communication == nil {
+		return "", errors.ArgumentMissing.With("communication")
+	}
+	type typing struct {
+		Type string `json:"type"`
+	}
+	return conversation.client.Post(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/communications/%s/typing", conversation.ID, communication.GetID()),
+		struct {
+			Typing typing `json:"typing"`
+		}{Typing: typing{Type: "On"}},
+		nil,
+	)
+}
+
+// UploadMedia uploads a media to be sent from the given communication
+//
+// The returned ID should be given to Send, SendText, or SendContent to attach the media to a message.
+func (conversation ConversationMessage) UploadMedia(context context.Context, communication Identifiable, name string, content *request.Content) (mediaID string, correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	if communication == nil {
+		return "", "", errors.ArgumentMissing.With("communication")
+	}
+	if len(name) == 0 {
+		return "", "", errors.ArgumentMissing.With("fileName")
+	}
+	if content == nil {
+		return "", "", errors.ArgumentMissing.With("content")
+	}
+	result := struct {
+		AttachmentID  string            `json:"attachmentId"`
+		UploadURL     string            `json:"uploadUrl"`
+		UploadHeaders map[string]string `json:"uploadHeaders"`
+	}{}
+	correlationID, err = conversation.client.Post(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/communications/%s/messages/media/uploads", conversation.ID, communication.GetID()),
+		struct {
+			FileName string `json:"fileName"`
+		}{FileName: name},
+		&result,
+	)
+	if err != nil {
+		return "", correlationID, err
+	}
+	upload := UploadURL{URL: result.UploadURL, UploadKey: result.AttachmentID, Headers: result.UploadHeaders}
+	if err = conversation.client.Upload(conversation.logger.ToContext(context), upload, content); err != nil {
+		return "", correlationID, err
+	}
+	return result.AttachmentID, correlationID, nil
+}
+
+// GetMessage gets a message of this Conversation
+func (conversation ConversationMessage) GetMessage(context context.Context, messageID string) (message *MessageData, correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	if len(messageID) == 0 {
+		return nil, "", errors.ArgumentMissing.With("messageId")
+	}
+	message = &MessageData{}
+	correlationID, err = conversation.client.Get(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/messages/%s", conversation.ID, messageID),
+		message,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return message, correlationID, nil
+}
+
+// GetHistory gets all the messages of this Conversation, oldest first
+//
+// The message IDs are collected from all participants and fetched in bulk.
+func (conversation ConversationMessage) GetHistory(context context.Context) (messages []*MessageData, correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	details := struct {
+		Participants []struct {
+			Messages []MessageDetails `json:"messages"`
+		} `json:"participants"`
+	}{}
+	if correlationID, err = conversation.client.Get(conversation.logger.ToContext(context), conversation.GetURI(), &details); err != nil {
+		return nil, correlationID, err
+	}
+	messageIDs := []string{}
+	seen := map[string]bool{}
+	for _, participant := range details.Participants {
+		for _, message := range participant.Messages {
+			if len(message.ID) > 0 && !seen[message.ID] {
+				seen[message.ID] = true
+				messageIDs = append(messageIDs, message.ID)
+			}
+		}
+	}
+	if len(messageIDs) == 0 {
+		return []*MessageData{}, correlationID, nil
+	}
+	entities := struct {
+		Entities []*MessageData `json:"entities"`
+	}{}
+	correlationID, err = conversation.client.Post(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/messages/bulk", conversation.ID),
+		messageIDs,
+		&entities,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	sort.SliceStable(entities.Entities, func(i, j int) bool {
+		return entities.Entities[i].Timestamp.Before(entities.Entities[j].Timestamp)
+	})
+	return entities.Entities, correlationID, nil
+}
+
+// patchParticipant sends a PATCH request about a participant of this Conversation
+func (conversation ConversationMessage) patchParticipant(context context.Context, identifiable Identifiable, payload interface{}) (correlationID string, err error) {
+	if err = conversation.checkInitialized(); err != nil {
+		return
+	}
+	return conversation.client.Patch(
+		conversation.logger.ToContext(context),
+		NewURI("/conversations/messages/%s/participants/%s", conversation.ID, identifiable.GetID()),
+		payload,
+		nil,
+	)
+}
+
+// checkInitialized checks if this Conversation can send requests
+func (conversation ConversationMessage) checkInitialized() error {
+	return checkInitialized(conversation.client, "Message Conversation", conversation.ID)
 }
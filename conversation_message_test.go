@@ -0,0 +1,169 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/gildas/go-request"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ConversationMessageSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestConversationMessageSuite(t *testing.T) {
+	suite.Run(t, new(ConversationMessageSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ConversationMessageSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ConversationMessageSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ConversationMessageSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ConversationMessageSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ConversationMessageSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+func (suite *ConversationMessageSuite) TestCanSendMessages() {
+	conversationID := uuid.New()
+	communicationID := uuid.New()
+	path := fmt.Sprintf("/api/v2/conversations/messages/%s/communications/%s", conversationID, communicationID)
+	server := CreateRecordingTestServer(map[string]any{
+		"POST " + path + "/messages": map[string]any{"id": "message-1", "direction": "outbound", "textBody": "Hello"},
+		"POST " + path + "/typing":   struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	conversation := gcloudcx.New[gcloudcx.ConversationMessage](context.Background(), client, conversationID, suite.Logger)
+	communication := gcloudcx.ConversationMessage{ID: communicationID}
+
+	_, err := conversation.SetTyping(context.Background(), communication)
+	suite.Require().NoErrorf(err, "Failed to send typing. %s", err)
+	suite.Assert().JSONEq(`{"typing": {"type": "On"}}`, string(server.LastRequest().Body))
+
+	message, _, err := conversation.SendText(context.Background(), communication, "Hello")
+	suite.Require().NoErrorf(err, "Failed to send text. %s", err)
+	suite.Assert().Equal("message-1", message.ID)
+	suite.Assert().JSONEq(`{"textBody": "Hello"}`, string(server.LastRequest().Body))
+
+	_, _, err = conversation.SendContent(context.Background(), communication, gcloudcx.NormalizedMessageQuickReplyContent{Text: "Yes", Payload: "yes"})
+	suite.Require().NoErrorf(err, "Failed to send content. %s", err)
+	suite.Assert().JSONEq(`{
+		"useNormalizedMessage": true,
+		"normalizedContent": [{"contentType": "QuickReply", "quickReply": {"text": "Yes", "payload": "yes"}}]
+	}`, string(server.LastRequest().Body))
+
+	_, _, err = conversation.SendText(context.Background(), communication, "")
+	suite.Assert().Error(err, "Sending an empty message should fail")
+}
+
+func (suite *ConversationMessageSuite) TestCanUploadMedia() {
+	conversationID := uuid.New()
+	communicationID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"PUT /uploads/media": struct{}{},
+		fmt.Sprintf("POST /api/v2/conversations/messages/%s/communications/%s/messages", conversationID, communicationID): map[string]any{"id": "message-1"},
+	})
+	defer server.Close()
+	server.Responses[fmt.Sprintf("POST /api/v2/conversations/messages/%s/communications/%s/messages/media/uploads", conversationID, communicationID)] = map[string]any{
+		"attachmentId":  "media-1",
+		"uploadUrl":     server.URL + "/uploads/media",
+		"uploadHeaders": map[string]string{"x-amz-tagging": "abc"},
+	}
+	client := CreateTestClient(server.URL, suite.Logger)
+	conversation := gcloudcx.New[gcloudcx.ConversationMessage](context.Background(), client, conversationID, suite.Logger)
+	communication := gcloudcx.ConversationMessage{ID: communicationID}
+
+	mediaID, _, err := conversation.UploadMedia(context.Background(), communication, "photo.png", request.ContentWithData([]byte("PNG"), "image/png"))
+	suite.Require().NoErrorf(err, "Failed to upload media. %s", err)
+	suite.Assert().Equal("media-1", mediaID)
+	suite.Assert().Equal("PNG", string(server.LastRequest().Body))
+
+	_, _, err = conversation.SendText(context.Background(), communication, "", mediaID)
+	suite.Require().NoErrorf(err, "Failed to send media. %s", err)
+	suite.Assert().JSONEq(`{"mediaIds": ["media-1"]}`, string(server.LastRequest().Body))
+}
+
+func (suite *ConversationMessageSuite) TestCanGetHistory() {
+	conversationID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET /api/v2/conversations/messages/" + conversationID.String(): map[string]any{
+			"id": conversationID,
+			"participants": []map[string]any{
+				{"messages": []map[string]any{{"messageId": "message-1"}, {"messageId": "message-3"}}},
+				{"messages": []map[string]any{{"messageId": "message-2"}}},
+			},
+		},
+		fmt.Sprintf("POST /api/v2/conversations/messages/%s/messages/bulk", conversationID): map[string]any{
+			"entities": []map[string]any{
+				{"id": "message-3", "timestamp": "2024-01-01T10:00:30Z", "direction": "inbound", "textBody": "Thanks"},
+				{"id": "message-1", "timestamp": "2024-01-01T10:00:00Z", "direction": "inbound", "textBody": "Hi"},
+				{"id": "message-2", "timestamp": "2024-01-01T10:00:10Z", "direction": "outbound", "textBody": "Hello"},
+			},
+		},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	conversation := gcloudcx.New[gcloudcx.ConversationMessage](context.Background(), client, conversationID, suite.Logger)
+
+	messages, _, err := conversation.GetHistory(context.Background())
+	suite.Require().NoErrorf(err, "Failed to get history. %s", err)
+	suite.Require().Len(messages, 3)
+	suite.Assert().Equal("message-1", messages[0].ID)
+	suite.Assert().Equal("message-2", messages[1].ID)
+	suite.Assert().Equal("message-3", messages[2].ID)
+	suite.Assert().JSONEq(`["message-1", "message-3", "message-2"]`, string(server.LastRequest().Body))
+}
@@ -0,0 +1,284 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gildas/go-errors"
+)
+
+// ConversationState describes the state of a Participant or of one of its communications
+//
+// Values not known by this package are preserved as they are.
+type ConversationState string
+
+// Conversation states
+const (
+	ConversationStateAlerting     ConversationState = "alerting"
+	ConversationStateDialing      ConversationState = "dialing"
+	ConversationStateContacting   ConversationState = "contacting"
+	ConversationStateOffering     ConversationState = "offering"
+	ConversationStateConnected    ConversationState = "connected"
+	ConversationStateDisconnected ConversationState = "disconnected"
+	ConversationStateTerminated   ConversationState = "terminated"
+	ConversationStateConverting   ConversationState = "converting"
+	ConversationStateUploading    ConversationState = "uploading"
+	ConversationStateTransmitting ConversationState = "transmitting"
+	ConversationStateScheduled    ConversationState = "scheduled"
+	ConversationStateNone         ConversationState = "none"
+)
+
+var conversationStates = []string{"alerting", "dialing", "contacting", "offering", "connected", "disconnected", "terminated", "converting", "uploading", "transmitting", "scheduled", "none"}
+
+// conversationStateTransitions lists the states a state can move to
+var conversationStateTransitions = map[ConversationState][]ConversationState{
+	ConversationStateAlerting:     {ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateDialing:      {ConversationStateContacting, ConversationStateAlerting, ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateContacting:   {ConversationStateAlerting, ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateOffering:     {ConversationStateContacting, ConversationStateAlerting, ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateConnected:    {ConversationStateConverting, ConversationStateUploading, ConversationStateTransmitting, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateConverting:   {ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateUploading:    {ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateTransmitting: {ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateScheduled:    {ConversationStateDialing, ConversationStateContacting, ConversationStateAlerting, ConversationStateConnected, ConversationStateDisconnected, ConversationStateTerminated},
+	ConversationStateDisconnected: {ConversationStateTerminated},
+	ConversationStateTerminated:   {},
+}
+
+// IsKnown tells if this state is known by this package
+func (state ConversationState) IsKnown() bool {
+	return isKnownEnum(string(state), conversationStates)
+}
+
+// Validate validates that this state is known
+//
+// This is used to reject typos before they reach the API
+func (state ConversationState) Validate() error {
+	if !state.IsKnown() {
+		return errors.ArgumentInvalid.With("state", string(state), strings.Join(conversationStates, ","))
+	}
+	return nil
+}
+
+// IsTerminal tells if this state is a final state (disconnected or terminated)
+func (state ConversationState) IsTerminal() bool {
+	return state == ConversationStateDisconnected || state == ConversationStateTerminated
+}
+
+// CanTransitionTo tells if a communication in this state can move to the given state
+//
+// Staying in the same state is always possible. If this state is unknown or none, any known state is accepted.
+func (state ConversationState) CanTransitionTo(next ConversationState) bool {
+	if !next.IsKnown() {
+		return false
+	}
+	if state == next {
+		return true
+	}
+	transitions, found := conversationStateTransitions[state]
+	if !found {
+		return true
+	}
+	for _, transition := range transitions {
+		if transition == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition validates that a communication in this state can move to the given state
+func (state ConversationState) ValidateTransition(next ConversationState) error {
+	if err := next.Validate(); err != nil {
+		return err
+	}
+	if !state.CanTransitionTo(next) {
+		return errors.ArgumentInvalid.With("state", string(next), "transition from "+string(state))
+	}
+	return nil
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (state ConversationState) String() string {
+	return string(state)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+//
+// Known states are normalized to lower case, unknown states are kept as they are
+func (state *ConversationState) UnmarshalJSON(payload []byte) error {
+	value, err := unmarshalEnum(payload, conversationStates)
+	if err != nil {
+		return err
+	}
+	*state = ConversationState(value)
+	return nil
+}
+
+// Direction describes the direction of a Participant or of one of its communications
+//
+// Values not known by this package are preserved as they are.
+type Direction string
+
+// Directions
+const (
+	DirectionInbound  Direction = "inbound"
+	DirectionOutbound Direction = "outbound"
+)
+
+var directions = []string{"inbound", "outbound"}
+
+// IsKnown tells if this direction is known by this package
+func (direction Direction) IsKnown() bool {
+	return isKnownEnum(string(direction), directions)
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (direction Direction) String() string {
+	return string(direction)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+//
+// Known directions are normalized to lower case, unknown directions are kept as they are
+func (direction *Direction) UnmarshalJSON(payload []byte) error {
+	value, err := unmarshalEnum(payload, directions)
+	if err != nil {
+		return err
+	}
+	*direction = Direction(value)
+	return nil
+}
+
+// ParticipantPurpose describes the purpose of a Participant in a Conversation
+//
+// Values not known by this package are preserved as they are.
+type ParticipantPurpose string
+
+// Participant purposes
+const (
+	ParticipantPurposeCustomer  ParticipantPurpose = "customer"
+	ParticipantPurposeExternal  ParticipantPurpose = "external"
+	ParticipantPurposeAgent     ParticipantPurpose = "agent"
+	ParticipantPurposeUser      ParticipantPurpose = "user"
+	ParticipantPurposeACD       ParticipantPurpose = "acd"
+	ParticipantPurposeIVR       ParticipantPurpose = "ivr"
+	ParticipantPurposeWorkflow  ParticipantPurpose = "workflow"
+	ParticipantPurposeBot       ParticipantPurpose = "bot"
+	ParticipantPurposeVoicemail ParticipantPurpose = "voicemail"
+	ParticipantPurposeStation   ParticipantPurpose = "station"
+	ParticipantPurposeDialer    ParticipantPurpose = "dialer.system"
+	ParticipantPurposeFax       ParticipantPurpose = "fax"
+	ParticipantPurposeGroup     ParticipantPurpose = "group"
+	ParticipantPurposeAPI       ParticipantPurpose = "api"
+)
+
+var participantPurposes = []string{"customer", "external", "agent", "user", "acd", "ivr", "workflow", "bot", "voicemail", "station", "dialer.system", "fax", "group", "api"}
+
+// IsKnown tells if this purpose is known by this package
+func (purpose ParticipantPurpose) IsKnown() bool {
+	return isKnownEnum(string(purpose), participantPurposes)
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (purpose ParticipantPurpose) String() string {
+	return string(purpose)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+//
+// Known purposes are normalized to lower case, unknown purposes are kept as they are
+func (purpose *ParticipantPurpose) UnmarshalJSON(payload []byte) error {
+	value, err := unmarshalEnum(payload, participantPurposes)
+	if err != nil {
+		return err
+	}
+	*purpose = ParticipantPurpose(value)
+	return nil
+}
+
+// DisconnectType describes how a Participant or one of its communications got disconnected
+//
+// Values not known by this package are preserved as they are.
+type DisconnectType string
+
+// Disconnect types
+const (
+	DisconnectTypeEndpoint             DisconnectType = "endpoint"
+	DisconnectTypeClient               DisconnectType = "client"
+	DisconnectTypeSystem               DisconnectType = "system"
+	DisconnectTypeTransfer             DisconnectType = "transfer"
+	DisconnectTypeTimeout              DisconnectType = "timeout"
+	DisconnectTypeTransferConference   DisconnectType = "transfer.conference"
+	DisconnectTypeTransferConsult      DisconnectType = "transfer.consult"
+	DisconnectTypeTransferForward      DisconnectType = "transfer.forward"
+	DisconnectTypeTransferNoAnswer     DisconnectType = "transfer.noanswer"
+	DisconnectTypeTransferNotAvailable DisconnectType = "transfer.notavailable"
+	DisconnectTypeTransportFailure     DisconnectType = "transport.failure"
+	DisconnectTypeError                DisconnectType = "error"
+	DisconnectTypePeer                 DisconnectType = "peer"
+	DisconnectTypeOther                DisconnectType = "other"
+	DisconnectTypeSpam                 DisconnectType = "spam"
+	DisconnectTypeUncallable           DisconnectType = "uncallable"
+)
+
+var disconnectTypes = []string{"endpoint", "client", "system", "transfer", "timeout", "transfer.conference", "transfer.consult", "transfer.forward", "transfer.noanswer", "transfer.notavailable", "transport.failure", "error", "peer", "other", "spam", "uncallable"}
+
+// IsKnown tells if this disconnect type is known by this package
+func (disconnectType DisconnectType) IsKnown() bool {
+	return isKnownEnum(string(disconnectType), disconnectTypes)
+}
+
+// IsTransfer tells if the disconnection was caused by a transfer
+func (disconnectType DisconnectType) IsTransfer() bool {
+	return disconnectType == DisconnectTypeTransfer || strings.HasPrefix(string(disconnectType), "transfer.")
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (disconnectType DisconnectType) String() string {
+	return string(disconnectType)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+//
+// Known disconnect types are normalized to lower case, unknown disconnect types are kept as they are
+func (disconnectType *DisconnectType) UnmarshalJSON(payload []byte) error {
+	value, err := unmarshalEnum(payload, disconnectTypes)
+	if err != nil {
+		return err
+	}
+	*disconnectType = DisconnectType(value)
+	return nil
+}
+
+// isKnownEnum tells if the value is one of the known values
+func isKnownEnum(value string, known []string) bool {
+	for _, candidate := range known {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalEnum unmarshals a JSON string, normalizing it if it matches a known value regardless of its case
+func unmarshalEnum(payload []byte, known []string) (string, error) {
+	var value string
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return "", errors.JSONUnmarshalError.Wrap(err)
+	}
+	for _, candidate := range known {
+		if strings.EqualFold(value, candidate) {
+			return candidate, nil
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,128 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ConversationStateSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestConversationStateSuite(t *testing.T) {
+	suite.Run(t, new(ConversationStateSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ConversationStateSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ConversationStateSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ConversationStateSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ConversationStateSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ConversationStateSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+func (suite *ConversationStateSuite) TestCanUnmarshalEnums() {
+	var participant gcloudcx.Participant
+	err := json.Unmarshal([]byte(`{"id": "`+uuid.NewString()+`", "state": "CONNECTED", "direction": "Inbound", "purpose": "agent", "disconnectType": "something.new"}`), &participant)
+	suite.Require().NoErrorf(err, "Failed to unmarshal participant. %s", err)
+	suite.Assert().Equal(gcloudcx.ConversationStateConnected, participant.State)
+	suite.Assert().Equal(gcloudcx.DirectionInbound, participant.Direction)
+	suite.Assert().Equal(gcloudcx.ParticipantPurposeAgent, participant.Purpose)
+	suite.Assert().Equal(gcloudcx.DisconnectType("something.new"), participant.DisconnectType, "Unknown values should be preserved")
+	suite.Assert().False(participant.DisconnectType.IsKnown())
+
+	payload, err := json.Marshal(participant)
+	suite.Require().NoErrorf(err, "Failed to marshal participant. %s", err)
+	var roundtrip gcloudcx.Participant
+	suite.Require().NoError(json.Unmarshal(payload, &roundtrip))
+	suite.Assert().Equal(participant.State, roundtrip.State)
+	suite.Assert().Equal(participant.DisconnectType, roundtrip.DisconnectType)
+	suite.Assert().True(gcloudcx.DisconnectTypeTransferConsult.IsTransfer())
+}
+
+func (suite *ConversationStateSuite) TestCanValidateTransitions() {
+	suite.Assert().NoError(gcloudcx.ConversationStateAlerting.ValidateTransition(gcloudcx.ConversationStateConnected))
+	suite.Assert().NoError(gcloudcx.ConversationStateConnected.ValidateTransition(gcloudcx.ConversationStateDisconnected))
+	suite.Assert().NoError(gcloudcx.ConversationStateConnected.ValidateTransition(gcloudcx.ConversationStateConnected))
+	suite.Assert().NoError(gcloudcx.ConversationState("").ValidateTransition(gcloudcx.ConversationStateConnected), "An unknown current state should accept any known state")
+	suite.Assert().Error(gcloudcx.ConversationStateDisconnected.ValidateTransition(gcloudcx.ConversationStateConnected))
+	suite.Assert().Error(gcloudcx.ConversationStateTerminated.ValidateTransition(gcloudcx.ConversationStateAlerting))
+	suite.Assert().Error(gcloudcx.ConversationStateAlerting.ValidateTransition("conected"))
+}
+
+func (suite *ConversationStateSuite) TestShouldNotUpdateInvalidState() {
+	conversationID := uuid.New()
+	participant := &gcloudcx.Participant{ID: uuid.New(), State: gcloudcx.ConversationStateDisconnected}
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PATCH /api/v2/conversations/chats/%s/participants/%s", conversationID, participant.ID): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	chat := gcloudcx.New[gcloudcx.ConversationChat](context.Background(), client, conversationID, suite.Logger)
+
+	_, err := chat.UpdateState(context.Background(), participant, "conected")
+	suite.Assert().Error(err, "A typo in the state should be rejected")
+	_, err = participant.UpdateState(context.Background(), chat, gcloudcx.ConversationStateConnected)
+	suite.Assert().Error(err, "A disconnected participant cannot be connected again")
+	suite.Assert().Empty(server.Requests, "No request should have been sent")
+
+	_, err = participant.UpdateState(context.Background(), chat, gcloudcx.ConversationStateTerminated)
+	suite.Require().NoErrorf(err, "Failed to update state. %s", err)
+	suite.Assert().JSONEq(`{"state": "terminated"}`, string(server.LastRequest().Body))
+}
@@ -0,0 +1,646 @@
+package gcloudcx
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// ConversationSummary describes the timeline of a conversation assembled by a ConversationTracker from the detail events
+type ConversationSummary struct {
+	ConversationID uuid.UUID
+	MediaType      string
+	Direction      Direction
+	ANI            string
+	DNIS           string
+	StartTime      time.Time
+	EndTime        time.Time
+	DisconnectType DisconnectType // how the customer left the conversation
+	Flows          []*ConversationFlowSegment
+	Queues         []*ConversationQueueSegment
+	Agents         []*ConversationAgentSegment
+	Completed      bool // true when the customer and all agents have left the conversation and the agents are wrapped up
+	Stale          bool // true when the conversation was dropped by the tracker while the customer was still connected
+	UpdatedAt      time.Time
+}
+
+// ConversationFlowSegment describes the time a conversation spent in a flow (IVR, inbound flow, etc)
+type ConversationFlowSegment struct {
+	ParticipantID  uuid.UUID
+	FlowID         uuid.UUID
+	FlowType       string
+	StartTime      time.Time
+	EndTime        time.Time
+	ExitReason     string
+	DisconnectType DisconnectType
+	TransferType   string
+	TransferTarget string
+}
+
+// ConversationQueueSegment describes the time a conversation waited in a queue
+type ConversationQueueSegment struct {
+	ParticipantID  uuid.UUID
+	QueueID        uuid.UUID
+	StartTime      time.Time
+	EndTime        time.Time
+	Outcome        string // ANSWERED, ABANDONED, FLOWOUT, etc
+	DisconnectType DisconnectType
+}
+
+// ConversationAgentSegment describes the time an agent spent in a conversation
+type ConversationAgentSegment struct {
+	ParticipantID  uuid.UUID
+	UserID         uuid.UUID
+	QueueID        uuid.UUID
+	StartTime      time.Time
+	EndTime        time.Time
+	DisconnectType DisconnectType
+	Holds          []*ConversationHold
+	WrapupCode     string
+	WrapupNotes    string
+	ACWDuration    time.Duration
+	WrappedUp      bool
+}
+
+// ConversationHold describes a hold of a conversation by an agent
+type ConversationHold struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// ConversationTrackerOptions defines the options of a ConversationTracker
+type ConversationTrackerOptions struct {
+	// WrapupTimeout is how long to wait for the wrap-up of the agents after the customer left. Default (or when not positive): 5 minutes
+	WrapupTimeout time.Duration
+	// StaleTimeout is how long to keep conversations whose customer is still connected without receiving events. Default (or when not positive): 4 hours
+	StaleTimeout time.Duration
+	Logger       *logger.Logger
+}
+
+// ConversationTracker correlates the conversation detail events into ConversationSummary timelines
+//
+// The tracker is fed with the ConversationFlowStartTopic, ConversationFlowEndTopic, ConversationACDStartTopic,
+// ConversationACDEndTopic, ConversationUserStartTopic, ConversationUserEndTopic, ConversationACWTopic,
+// ConversationWrapupTopic, ConversationCustomerStartTopic, and ConversationCustomerEndTopic.
+// The user conversation topics (UserConversationCallTopic, etc) are used to track holds.
+type ConversationTracker struct {
+	WrapupTimeout time.Duration
+	StaleTimeout  time.Duration
+	conversations map[uuid.UUID]*ConversationSummary
+	customerEnded map[uuid.UUID]bool
+	subscribers   map[chan ConversationSummary]struct{}
+	mutex         sync.RWMutex
+	logger        *logger.Logger
+}
+
+// NewConversationTracker creates a new ConversationTracker
+func NewConversationTracker(options ConversationTrackerOptions) *ConversationTracker {
+	if options.WrapupTimeout <= 0 {
+		options.WrapupTimeout = 5 * time.Minute
+	}
+	if options.StaleTimeout <= 0 {
+		options.StaleTimeout = 4 * time.Hour
+	}
+	if options.Logger == nil {
+		options.Logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+	return &ConversationTracker{
+		WrapupTimeout: options.WrapupTimeout,
+		StaleTimeout:  options.StaleTimeout,
+		conversations: map[uuid.UUID]*ConversationSummary{},
+		customerEnded: map[uuid.UUID]bool{},
+		subscribers:   map[chan ConversationSummary]struct{}{},
+		logger:        options.Logger.Child("conversation_tracker", "conversation_tracker"),
+	}
+}
+
+// Topics gets the notification topics needed to track the given conversations
+//
+// Detail events are usually subscribed with a wildcard, e.g. "v2.detail.events.conversation.{id}.*",
+// this func is useful when the conversations are known in advance.
+func (tracker *ConversationTracker) Topics(conversations ...Identifiable) []NotificationTopic {
+	topics := make([]NotificationTopic, 0, len(conversations)*10)
+	for _, conversation := range conversations {
+		topics = append(topics,
+			ConversationFlowStartTopic{}.With(conversation),
+			ConversationFlowEndTopic{}.With(conversation),
+			ConversationACDStartTopic{}.With(conversation),
+			ConversationACDEndTopic{}.With(conversation),
+			ConversationUserStartTopic{}.With(conversation),
+			ConversationUserEndTopic{}.With(conversation),
+			ConversationACWTopic{}.With(conversation),
+			ConversationWrapupTopic{}.With(conversation),
+			ConversationCustomerStartTopic{}.With(conversation),
+			ConversationCustomerEndTopic{}.With(conversation),
+		)
+	}
+	return topics
+}
+
+// Run handles the topics received on the given channel until the channel is closed or the context is done
+//
+// Conversations whose customer left and that do not receive events for longer than WrapupTimeout are completed as they are.
+// Conversations whose customer is still connected and that do not receive events for longer than StaleTimeout are dropped.
+//
+// The expiration is checked every tenth of WrapupTimeout, an error is returned if that interval is not positive.
+func (tracker *ConversationTracker) Run(context context.Context, channel *NotificationChannel) error {
+	interval := tracker.WrapupTimeout / 10
+	if interval <= 0 {
+		return errors.ArgumentInvalid.With("wrapupTimeout", tracker.WrapupTimeout, "a duration of at least 10ns")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-context.Done():
+			return context.Err()
+		case now := <-ticker.C:
+			tracker.Expire(now)
+		case topic, ok := <-channel.TopicReceived:
+			if !ok {
+				return nil
+			}
+			tracker.Handle(topic)
+		}
+	}
+}
+
+// Handle updates the timeline of a conversation with the given topic
+//
+// Returns true if the topic was used by the tracker
+func (tracker *ConversationTracker) Handle(topic NotificationTopic) bool {
+	switch topic := topic.(type) {
+	case ConversationFlowStartTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.setMedia(topic.MediaType, topic.Direction, topic.ANI, topic.DNIS)
+			summary.Flows = append(summary.Flows, &ConversationFlowSegment{
+				ParticipantID: participantID(topic.Participant),
+				FlowID:        topic.FlowID,
+				FlowType:      topic.FlowType,
+				StartTime:     topic.Time,
+			})
+		})
+	case ConversationFlowEndTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			segment := summary.openFlow(participantID(topic.Participant), topic.FlowID)
+			if segment == nil {
+				segment = &ConversationFlowSegment{ParticipantID: participantID(topic.Participant), FlowID: topic.FlowID, FlowType: topic.FlowType}
+				summary.Flows = append(summary.Flows, segment)
+			}
+			segment.EndTime = topic.Time
+			segment.ExitReason = topic.ExitReason
+			segment.DisconnectType = topic.DisconnectType
+			segment.TransferType = topic.TransferType
+			segment.TransferTarget = topic.TransferTargetName
+			if len(segment.TransferTarget) == 0 {
+				segment.TransferTarget = topic.TransferTargetAddress
+			}
+		})
+	case ConversationACDStartTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.setMedia(topic.MediaType, topic.Direction, topic.ANI, topic.DNIS)
+			summary.Queues = append(summary.Queues, &ConversationQueueSegment{
+				ParticipantID: participantID(topic.Participant),
+				QueueID:       topic.QueueID,
+				StartTime:     topic.Time,
+			})
+		})
+	case ConversationACDEndTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			segment := summary.openQueue(participantID(topic.Participant), topic.QueueID)
+			if segment == nil {
+				segment = &ConversationQueueSegment{ParticipantID: participantID(topic.Participant), QueueID: topic.QueueID}
+				summary.Queues = append(summary.Queues, segment)
+			}
+			segment.EndTime = topic.Time
+			segment.Outcome = topic.ACDOutcome
+			segment.DisconnectType = topic.DisconnectType
+		})
+	case ConversationUserStartTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.setMedia(topic.MediaType, topic.Direction, topic.ANI, topic.DNIS)
+			summary.Agents = append(summary.Agents, &ConversationAgentSegment{
+				ParticipantID: participantID(topic.Participant),
+				UserID:        topic.UserID,
+				QueueID:       topic.QueueID,
+				StartTime:     topic.Time,
+			})
+		})
+	case ConversationUserEndTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			segment := summary.agent(participantID(topic.Participant), topic.UserID)
+			if segment == nil {
+				segment = &ConversationAgentSegment{ParticipantID: participantID(topic.Participant), UserID: topic.UserID, QueueID: topic.QueueID}
+				summary.Agents = append(summary.Agents, segment)
+			}
+			segment.EndTime = topic.Time
+			segment.DisconnectType = topic.DisconnectType
+			if hold := segment.openHold(); hold != nil {
+				hold.EndTime = topic.Time
+			}
+		})
+	case ConversationACWTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.wrapup(participantID(topic.Participant), topic.UserID, topic.WrapupCode, topic.WrapupNotes, topic.WrapupDuration)
+		})
+	case ConversationWrapupTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.wrapup(participantID(topic.Participant), topic.UserID, topic.WrapupCode, topic.WrapupNotes, topic.WrapupDuration)
+		})
+	case ConversationCustomerStartTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.setMedia(topic.MediaType, topic.Direction, topic.ANI, topic.DNIS)
+		})
+	case ConversationCustomerEndTopic:
+		return tracker.update(topic.ConversationID, topic.Time, func(summary *ConversationSummary) {
+			summary.DisconnectType = topic.DisconnectType
+			tracker.customerEnded[summary.ConversationID] = true
+		})
+	case UserConversationCallTopic:
+		return tracker.handleHold(topic.ConversationID, topic.User, topic.Participants)
+	case UserConversationCallbackTopic:
+		return tracker.handleHold(topic.ConversationID, topic.User, topic.Participants)
+	case UserConversationChatTopic:
+		return tracker.handleHold(topic.ConversationID, topic.User, topic.Participants)
+	case UserConversationEmailTopic:
+		return tracker.handleHold(topic.ConversationID, topic.User, topic.Participants)
+	case UserConversationMessageTopic:
+		return tracker.handleHold(topic.ConversationID, topic.User, topic.Participants)
+	}
+	return false
+}
+
+// handleHold tracks the holds of an agent from a user conversation topic
+//
+// Only conversations already known to the tracker are updated
+func (tracker *ConversationTracker) handleHold(conversationID uuid.UUID, user *User, participants []*Participant) bool {
+	if user == nil {
+		return false
+	}
+	tracker.mutex.RLock()
+	_, found := tracker.conversations[conversationID]
+	tracker.mutex.RUnlock()
+	if !found {
+		return false
+	}
+	for _, participant := range participants {
+		if participant.User == nil || participant.User.ID != user.ID || !participant.EndTime.IsZero() {
+			continue
+		}
+		now := time.Now().UTC()
+		return tracker.update(conversationID, time.Time{}, func(summary *ConversationSummary) {
+			segment := summary.agent(participant.ID, user.ID)
+			if segment == nil {
+				return
+			}
+			hold := segment.openHold()
+			if participant.Held && hold == nil {
+				start := participant.StartHoldTime
+				if start.IsZero() {
+					start = now
+				}
+				segment.Holds = append(segment.Holds, &ConversationHold{StartTime: start})
+			} else if !participant.Held && hold != nil {
+				hold.EndTime = now
+			}
+		})
+	}
+	return false
+}
+
+// update applies an update to a conversation and completes it if possible
+func (tracker *ConversationTracker) update(conversationID uuid.UUID, eventTime time.Time, update func(summary *ConversationSummary)) bool {
+	if conversationID == uuid.Nil {
+		return false
+	}
+	tracker.mutex.Lock()
+	summary, found := tracker.conversations[conversationID]
+	if !found {
+		summary = &ConversationSummary{ConversationID: conversationID, StartTime: eventTime}
+		tracker.conversations[conversationID] = summary
+	}
+	if !eventTime.IsZero() {
+		if summary.StartTime.IsZero() || eventTime.Before(summary.StartTime) {
+			summary.StartTime = eventTime
+		}
+		if eventTime.After(summary.EndTime) {
+			summary.EndTime = eventTime
+		}
+	}
+	update(summary)
+	summary.UpdatedAt = time.Now().UTC()
+	var completed *ConversationSummary
+	if tracker.customerEnded[conversationID] && summary.isDone() {
+		completed = tracker.complete(summary)
+	}
+	tracker.mutex.Unlock()
+	if completed != nil {
+		tracker.notify(*completed)
+	}
+	return true
+}
+
+// Expire removes the conversations that stopped receiving events
+//
+// Conversations whose customer left are completed after WrapupTimeout.
+// Conversations whose customer is still connected are dropped after StaleTimeout, their summary is sent with Stale set and Completed unset.
+//
+// Run calls Expire periodically
+func (tracker *ConversationTracker) Expire(now time.Time) {
+	expired := []ConversationSummary{}
+	tracker.mutex.Lock()
+	for _, summary := range tracker.conversations {
+		idle := now.Sub(summary.UpdatedAt)
+		if tracker.customerEnded[summary.ConversationID] {
+			if idle >= tracker.WrapupTimeout {
+				expired = append(expired, *tracker.complete(summary))
+			}
+		} else if idle >= tracker.StaleTimeout {
+			expired = append(expired, *tracker.drop(summary))
+		}
+	}
+	tracker.mutex.Unlock()
+	tracker.notify(expired...)
+}
+
+// complete removes a conversation from the tracker and returns its completed summary
+//
+// the caller must hold the lock
+func (tracker *ConversationTracker) complete(summary *ConversationSummary) *ConversationSummary {
+	delete(tracker.conversations, summary.ConversationID)
+	delete(tracker.customerEnded, summary.ConversationID)
+	summary.Completed = true
+	tracker.logger.Infof("Conversation %s is completed", summary.ConversationID)
+	return summary.clone()
+}
+
+// drop removes a stale conversation from the tracker and returns its summary
+//
+// the caller must hold the lock
+func (tracker *ConversationTracker) drop(summary *ConversationSummary) *ConversationSummary {
+	delete(tracker.conversations, summary.ConversationID)
+	delete(tracker.customerEnded, summary.ConversationID)
+	summary.Stale = true
+	tracker.logger.Warnf("Conversation %s is stale, no event since %s", summary.ConversationID, summary.UpdatedAt)
+	return summary.clone()
+}
+
+// Get gets a snapshot of the timeline of a conversation in progress
+func (tracker *ConversationTracker) Get(conversation Identifiable) (ConversationSummary, bool) {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	if summary, found := tracker.conversations[conversation.GetID()]; found {
+		return *summary.clone(), true
+	}
+	return ConversationSummary{}, false
+}
+
+// Active gets a snapshot of the timelines of the conversations in progress
+func (tracker *ConversationTracker) Active() []ConversationSummary {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	summaries := make([]ConversationSummary, 0, len(tracker.conversations))
+	for _, summary := range tracker.conversations {
+		summaries = append(summaries, *summary.clone())
+	}
+	return summaries
+}
+
+// Completions gets a chan that receives the completed conversations
+//
+// Stale conversations dropped by Expire are sent too, with Stale set and Completed unset.
+//
+// Summaries are dropped if the chan is full, the buffer should be sized accordingly.
+// Call the returned func to stop receiving summaries, the chan is closed then.
+func (tracker *ConversationTracker) Completions(buffer int) (<-chan ConversationSummary, func()) {
+	completions := make(chan ConversationSummary, buffer)
+	tracker.mutex.Lock()
+	tracker.subscribers[completions] = struct{}{}
+	tracker.mutex.Unlock()
+	var once sync.Once
+	return completions, func() {
+		once.Do(func() {
+			tracker.mutex.Lock()
+			delete(tracker.subscribers, completions)
+			close(completions)
+			tracker.mutex.Unlock()
+		})
+	}
+}
+
+// notify sends completed summaries to the subscribers
+func (tracker *ConversationTracker) notify(summaries ...ConversationSummary) {
+	tracker.mutex.RLock()
+	defer tracker.mutex.RUnlock()
+	for _, summary := range summaries {
+		for subscriber := range tracker.subscribers {
+			select {
+			case subscriber <- summary:
+			default:
+				tracker.logger.Warnf("Dropped the summary of conversation %s, the subscriber is too slow", summary.ConversationID)
+			}
+		}
+	}
+}
+
+// GetID gets the identifier of this
+//
+//	implements Identifiable
+func (summary ConversationSummary) GetID() uuid.UUID {
+	return summary.ConversationID
+}
+
+// FlowDuration gets the total time the conversation spent in flows
+func (summary ConversationSummary) FlowDuration() (duration time.Duration) {
+	for _, segment := range summary.Flows {
+		duration += segmentDuration(segment.StartTime, segment.EndTime)
+	}
+	return
+}
+
+// QueueWait gets the total time the conversation waited in queues
+func (summary ConversationSummary) QueueWait() (duration time.Duration) {
+	for _, segment := range summary.Queues {
+		duration += segmentDuration(segment.StartTime, segment.EndTime)
+	}
+	return
+}
+
+// HoldDuration gets the total time the conversation was held by agents
+func (summary ConversationSummary) HoldDuration() (duration time.Duration) {
+	for _, segment := range summary.Agents {
+		duration += segment.HoldDuration()
+	}
+	return
+}
+
+// WrapupCodes gets the wrap-up codes set by the agents
+func (summary ConversationSummary) WrapupCodes() []string {
+	codes := []string{}
+	for _, segment := range summary.Agents {
+		if len(segment.WrapupCode) > 0 && !slices.Contains(codes, segment.WrapupCode) {
+			codes = append(codes, segment.WrapupCode)
+		}
+	}
+	return codes
+}
+
+// Duration gets the duration of the agent segment, excluding ACW
+func (segment ConversationAgentSegment) Duration() time.Duration {
+	return segmentDuration(segment.StartTime, segment.EndTime)
+}
+
+// HoldDuration gets the total time the agent held the conversation
+func (segment ConversationAgentSegment) HoldDuration() (duration time.Duration) {
+	for _, hold := range segment.Holds {
+		duration += segmentDuration(hold.StartTime, hold.EndTime)
+	}
+	return
+}
+
+// setMedia sets the media information of the conversation from the first event that carries them
+func (summary *ConversationSummary) setMedia(mediaType string, direction Direction, ani, dnis string) {
+	if len(summary.MediaType) == 0 {
+		summary.MediaType = mediaType
+	}
+	if len(summary.Direction) == 0 {
+		summary.Direction = direction
+	}
+	if len(summary.ANI) == 0 {
+		summary.ANI = ani
+	}
+	if len(summary.DNIS) == 0 {
+		summary.DNIS = dnis
+	}
+}
+
+// openFlow finds the flow segment of a participant that is not ended yet
+func (summary *ConversationSummary) openFlow(participantID, flowID uuid.UUID) *ConversationFlowSegment {
+	for i := len(summary.Flows) - 1; i >= 0; i-- {
+		if segment := summary.Flows[i]; segment.ParticipantID == participantID && segment.FlowID == flowID && segment.EndTime.IsZero() {
+			return segment
+		}
+	}
+	return nil
+}
+
+// openQueue finds the queue segment of a participant that is not ended yet
+func (summary *ConversationSummary) openQueue(participantID, queueID uuid.UUID) *ConversationQueueSegment {
+	for i := len(summary.Queues) - 1; i >= 0; i-- {
+		if segment := summary.Queues[i]; segment.ParticipantID == participantID && segment.QueueID == queueID && segment.EndTime.IsZero() {
+			return segment
+		}
+	}
+	return nil
+}
+
+// agent finds the latest segment of an agent, by participant first, then by user
+func (summary *ConversationSummary) agent(participantID, userID uuid.UUID) *ConversationAgentSegment {
+	for i := len(summary.Agents) - 1; i >= 0; i-- {
+		if segment := summary.Agents[i]; segment.ParticipantID == participantID {
+			return segment
+		}
+	}
+	if userID != uuid.Nil {
+		for i := len(summary.Agents) - 1; i >= 0; i-- {
+			if segment := summary.Agents[i]; segment.UserID == userID {
+				return segment
+			}
+		}
+	}
+	return nil
+}
+
+// wrapup sets the wrap-up of an agent segment
+func (summary *ConversationSummary) wrapup(participantID, userID uuid.UUID, code, notes string, duration time.Duration) {
+	segment := summary.agent(participantID, userID)
+	if segment == nil {
+		segment = &ConversationAgentSegment{ParticipantID: participantID, UserID: userID}
+		summary.Agents = append(summary.Agents, segment)
+	}
+	if len(code) > 0 {
+		segment.WrapupCode = code
+	}
+	if len(notes) > 0 {
+		segment.WrapupNotes = notes
+	}
+	if duration > 0 {
+		segment.ACWDuration = duration
+	}
+	segment.WrappedUp = true
+}
+
+// isDone tells if all the segments of the conversation are ended and the agents are wrapped up
+func (summary *ConversationSummary) isDone() bool {
+	for _, segment := range summary.Flows {
+		if segment.EndTime.IsZero() {
+			return false
+		}
+	}
+	for _, segment := range summary.Queues {
+		if segment.EndTime.IsZero() {
+			return false
+		}
+	}
+	for _, segment := range summary.Agents {
+		if segment.EndTime.IsZero() || !segment.WrappedUp {
+			return false
+		}
+	}
+	return true
+}
+
+// openHold finds the hold of the segment that is not ended yet
+func (segment *ConversationAgentSegment) openHold() *ConversationHold {
+	if count := len(segment.Holds); count > 0 && segment.Holds[count-1].EndTime.IsZero() {
+		return segment.Holds[count-1]
+	}
+	return nil
+}
+
+// clone creates a deep copy of this
+func (summary *ConversationSummary) clone() *ConversationSummary {
+	clone := *summary
+	clone.Flows = make([]*ConversationFlowSegment, 0, len(summary.Flows))
+	for _, segment := range summary.Flows {
+		copied := *segment
+		clone.Flows = append(clone.Flows, &copied)
+	}
+	clone.Queues = make([]*ConversationQueueSegment, 0, len(summary.Queues))
+	for _, segment := range summary.Queues {
+		copied := *segment
+		clone.Queues = append(clone.Queues, &copied)
+	}
+	clone.Agents = make([]*ConversationAgentSegment, 0, len(summary.Agents))
+	for _, segment := range summary.Agents {
+		copied := *segment
+		copied.Holds = make([]*ConversationHold, 0, len(segment.Holds))
+		for _, hold := range segment.Holds {
+			held := *hold
+			copied.Holds = append(copied.Holds, &held)
+		}
+		clone.Agents = append(clone.Agents, &copied)
+	}
+	return &clone
+}
+
+// participantID gets the ID of a participant, uuid.Nil if the participant is nil
+func participantID(participant *Participant) uuid.UUID {
+	if participant == nil {
+		return uuid.Nil
+	}
+	return participant.ID
+}
+
+// segmentDuration gets the duration between start and end, 0 if the segment is not ended
+func segmentDuration(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
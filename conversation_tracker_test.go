@@ -0,0 +1,204 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ConversationTrackerSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestConversationTrackerSuite(t *testing.T) {
+	suite.Run(t, new(ConversationTrackerSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ConversationTrackerSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ConversationTrackerSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ConversationTrackerSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ConversationTrackerSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ConversationTrackerSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *ConversationTrackerSuite) TestCanTrackConversation() {
+	conversationID := uuid.MustParse("aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e")
+	agentID := uuid.MustParse("6408f799-973a-436a-9e1a-a75a6ddc46f5")
+	participantID := uuid.MustParse("b1f7a4e8-8a3c-4b0b-9d0d-1b0e0a9b3c4d")
+	queueID := uuid.MustParse("3c9d1b2a-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+	topics, err := gcloudcx.UnmarshalNotificationEnvelope(suite.LoadTestData("notification_detail_events_conversation.json"))
+	suite.Require().NoErrorf(err, "Failed to unmarshal the events. %s", err)
+	suite.Require().Len(topics, 9)
+
+	tracker := gcloudcx.NewConversationTracker(gcloudcx.ConversationTrackerOptions{Logger: suite.Logger})
+	completions, stop := tracker.Completions(1)
+	defer stop()
+
+	for _, topic := range topics[:6] {
+		suite.Require().Truef(tracker.Handle(topic), "The tracker should handle %s", topic)
+	}
+	holdStart := time.Date(2024, 6, 18, 17, 2, 0, 0, time.UTC)
+	suite.Assert().True(tracker.Handle(gcloudcx.UserConversationCallTopic{
+		User:           &gcloudcx.User{ID: agentID},
+		ConversationID: conversationID,
+		Participants: []*gcloudcx.Participant{
+			{ID: participantID, User: &gcloudcx.User{ID: agentID}, Held: true, StartHoldTime: holdStart},
+		},
+	}), "The tracker should handle the hold")
+	for _, topic := range topics[6:8] {
+		suite.Require().Truef(tracker.Handle(topic), "The tracker should handle %s", topic)
+	}
+
+	summary, found := tracker.Get(gcloudcx.Conversation{ID: conversationID})
+	suite.Require().True(found, "The conversation should be in progress")
+	suite.Assert().False(summary.Completed, "The conversation should wait for the wrap-up")
+	suite.Assert().Len(tracker.Active(), 1)
+	suite.Assert().Empty(completions)
+
+	suite.Require().True(tracker.Handle(topics[8]), "The tracker should handle the ACW")
+	suite.Require().Len(completions, 1)
+	summary = <-completions
+	suite.Assert().Empty(tracker.Active())
+	suite.Assert().True(summary.Completed)
+	suite.Assert().Equal(conversationID, summary.ConversationID)
+	suite.Assert().Equal("VOICE", summary.MediaType)
+	suite.Assert().Equal(gcloudcx.DirectionInbound, summary.Direction)
+	suite.Assert().Equal(gcloudcx.DisconnectTypePeer, summary.DisconnectType)
+	suite.Assert().Equal(390261*time.Millisecond, summary.EndTime.Sub(summary.StartTime))
+
+	suite.Require().Len(summary.Flows, 1)
+	suite.Assert().Equal("INBOUNDCALL", summary.Flows[0].FlowType)
+	suite.Assert().Equal("TRANSFER", summary.Flows[0].ExitReason)
+	suite.Assert().Equal("Support", summary.Flows[0].TransferTarget)
+	suite.Assert().Equal(30*time.Second, summary.FlowDuration())
+
+	suite.Require().Len(summary.Queues, 1)
+	suite.Assert().Equal(queueID, summary.Queues[0].QueueID)
+	suite.Assert().Equal("ANSWERED", summary.Queues[0].Outcome)
+	suite.Assert().Equal(30*time.Second, summary.QueueWait())
+
+	suite.Require().Len(summary.Agents, 1)
+	agent := summary.Agents[0]
+	suite.Assert().Equal(agentID, agent.UserID)
+	suite.Assert().Equal(participantID, agent.ParticipantID)
+	suite.Assert().Equal(gcloudcx.DisconnectTypeClient, agent.DisconnectType)
+	suite.Assert().Equal(300050*time.Millisecond, agent.Duration())
+	suite.Require().Len(agent.Holds, 1)
+	suite.Assert().Equal(holdStart, agent.Holds[0].StartTime)
+	suite.Assert().Equal(agent.EndTime, agent.Holds[0].EndTime, "The hold should end with the agent segment")
+	suite.Assert().Equal("Customer happy", agent.WrapupNotes)
+	suite.Assert().Equal(30*time.Second, agent.ACWDuration)
+	suite.Assert().Equal([]string{"7fb334b0-0e9e-11e4-9191-0800200c9a66"}, summary.WrapupCodes())
+}
+
+func (suite *ConversationTrackerSuite) TestCanExpireConversation() {
+	topics, err := gcloudcx.UnmarshalNotificationEnvelope(suite.LoadTestData("notification_detail_events_conversation.json"))
+	suite.Require().NoErrorf(err, "Failed to unmarshal the events. %s", err)
+
+	tracker := gcloudcx.NewConversationTracker(gcloudcx.ConversationTrackerOptions{WrapupTimeout: time.Minute, Logger: suite.Logger})
+	completions, stop := tracker.Completions(1)
+	defer stop()
+
+	for _, topic := range topics[:8] {
+		tracker.Handle(topic)
+	}
+	tracker.Expire(time.Now())
+	suite.Assert().Empty(completions)
+	tracker.Expire(time.Now().Add(time.Minute))
+	suite.Require().Len(completions, 1)
+	summary := <-completions
+	suite.Assert().True(summary.Completed)
+	suite.Assert().Empty(summary.WrapupCodes())
+	suite.Assert().Empty(tracker.Active())
+	suite.Assert().False(tracker.Handle(gcloudcx.MetadataTopic{}), "The tracker should ignore other topics")
+}
+
+func (suite *ConversationTrackerSuite) TestShouldNotCompleteLiveConversation() {
+	topics, err := gcloudcx.UnmarshalNotificationEnvelope(suite.LoadTestData("notification_detail_events_conversation.json"))
+	suite.Require().NoErrorf(err, "Failed to unmarshal the events. %s", err)
+
+	tracker := gcloudcx.NewConversationTracker(gcloudcx.ConversationTrackerOptions{WrapupTimeout: time.Minute, StaleTimeout: time.Hour, Logger: suite.Logger})
+	completions, stop := tracker.Completions(1)
+	defer stop()
+
+	for _, topic := range topics[:6] { // the customer is still connected to the agent
+		tracker.Handle(topic)
+	}
+	tracker.Expire(time.Now().Add(10 * time.Minute))
+	suite.Assert().Empty(completions, "A live conversation should not expire after WrapupTimeout")
+	suite.Assert().Len(tracker.Active(), 1)
+
+	tracker.Expire(time.Now().Add(time.Hour))
+	suite.Require().Len(completions, 1)
+	summary := <-completions
+	suite.Assert().False(summary.Completed, "A stale conversation should not be reported as completed")
+	suite.Assert().True(summary.Stale)
+	suite.Assert().Empty(tracker.Active())
+}
+
+func (suite *ConversationTrackerSuite) TestShouldNotRunWithInvalidWrapupTimeout() {
+	tracker := gcloudcx.NewConversationTracker(gcloudcx.ConversationTrackerOptions{WrapupTimeout: -time.Minute, Logger: suite.Logger})
+	suite.Assert().Equal(5*time.Minute, tracker.WrapupTimeout, "A negative WrapupTimeout should use the default")
+
+	tracker.WrapupTimeout = 5 * time.Nanosecond
+	err := tracker.Run(context.Background(), nil)
+	suite.Require().Error(err, "The tracker should not run with an expiration interval under 1ns")
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+}
@@ -8,9 +8,9 @@ import (
 
 // ConversationVideo describes a Video (like belonging to Participant)
 type ConversationVideo struct {
-	ID    uuid.UUID `json:"id"`
-	Self  Address   `json:"self"`
-	State string    `json:"state"` // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
+	ID    uuid.UUID         `json:"id"`
+	Self  Address           `json:"self"`
+	State ConversationState `json:"state"` // alerting,dialing,contacting,offering,connected,disconnected,terminated,converting,uploading,transmitting,scheduled,none
 
 	Segments      []Segment `json:"segments"`
 	Provider      string    `json:"provider"`
@@ -26,5 +26,5 @@ type ConversationVideo struct {
 	DisconnectedTime  time.Time `json:"disconnectedTime"`
 	StartAlertingTime time.Time `json:"startAlertingTime"`
 
-	DisconnectType string `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
+	DisconnectType DisconnectType `json:"disconnectType"` // endpoint,client,system,transfer,timeout,transfer.conference,transfer.consult,transfer.forward,transfer.noanswer,transfer.notavailable,transport.failure,error,peer,other,spam,uncallable
 }
@@ -5,7 +5,5 @@ type EmailAddress struct {
 	Email string `json:"email"`
 }
 
-type EmailAttachment struct {
-	Domain DomainEntityRef    `json:"domain"`
-	Route  *EmailInboundRoute `json:"route"`
-}
+// EmailAttachment describes an attachment of an email message
+type EmailAttachment = Attachment
@@ -0,0 +1,56 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// EmailMessage describes a message of an Email conversation
+//
+// It is also used for drafts, see ConversationEmail.GetDraft
+type EmailMessage struct {
+	ID              string            `json:"id,omitempty"`
+	To              []EmailAddress    `json:"to"`
+	Cc              []EmailAddress    `json:"cc,omitempty"`
+	Bcc             []EmailAddress    `json:"bcc,omitempty"`
+	From            EmailAddress      `json:"from"`
+	ReplyTo         *EmailAddress     `json:"replyTo,omitempty"`
+	Subject         string            `json:"subject,omitempty"`
+	Attachments     []EmailAttachment `json:"attachments,omitempty"`
+	TextBody        string            `json:"textBody,omitempty"`
+	HTMLBody        string            `json:"htmlBody,omitempty"`
+	Time            time.Time         `json:"time"`
+	HistoryIncluded bool              `json:"historyIncluded,omitempty"`
+	State           string            `json:"state,omitempty"`     // Draft, Sent, Queued, Received
+	DraftType       string            `json:"draftType,omitempty"` // Reply, ReplyAll, Forward
+	EmailSizeBytes  int64             `json:"emailSizeBytes,omitempty"`
+	SelfURI         URI               `json:"selfUri,omitempty"`
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (message EmailMessage) String() string {
+	return message.Subject
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (message EmailMessage) MarshalJSON() ([]byte, error) {
+	type surrogate EmailMessage
+	var messageTime *time.Time
+	if !message.Time.IsZero() {
+		messageTime = &message.Time
+	}
+	data, err := json.Marshal(struct {
+		surrogate
+		Time *time.Time `json:"time,omitempty"`
+	}{
+		surrogate: surrogate(message),
+		Time:      messageTime,
+	})
+	return data, errors.JSONMarshalError.Wrap(err)
+}
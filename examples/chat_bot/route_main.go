@@ -11,7 +11,7 @@ import (
 )
 
 // findParticipant finds a participant after its user id and purpose
-func findParticipant(participants []*gcloudcx.Participant, user *gcloudcx.User, purpose string) *gcloudcx.Participant {
+func findParticipant(participants []*gcloudcx.Participant, user *gcloudcx.User, purpose gcloudcx.ParticipantPurpose) *gcloudcx.Participant {
 	for _, participant := range participants {
 		if participant.Purpose == purpose && participant.User != nil && user.ID == participant.User.ID {
 			return participant
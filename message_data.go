@@ -0,0 +1,55 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// MessageData describes a message sent or received in a Message Conversation
+type MessageData struct {
+	ID                string             `json:"id"`
+	ProviderMessageID string             `json:"providerMessageId,omitempty"`
+	Timestamp         time.Time          `json:"timestamp"`
+	FromAddress       string             `json:"fromAddress,omitempty"`
+	ToAddress         string             `json:"toAddress,omitempty"`
+	Direction         string             `json:"direction"`     // inbound, outbound
+	MessengerType     string             `json:"messengerType"` // sms, facebook, twitter, line, whatsapp, webmessaging, open, instagram, apple
+	TextBody          string             `json:"textBody,omitempty"`
+	Status            string             `json:"status,omitempty"` // queued, sent, failed, received, delivery-success, delivery-failed, read, removed
+	Media             []MessageMedia     `json:"media,omitempty"`
+	Stickers          []MessageSticker   `json:"stickers,omitempty"`
+	NormalizedMessage *NormalizedMessage `json:"normalizedMessage,omitempty"`
+	CreatedBy         *DomainEntityRef   `json:"createdBy,omitempty"`
+	ConversationID    uuid.UUID          `json:"conversationId,omitempty"`
+	SelfURI           URI                `json:"selfUri,omitempty"`
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (message MessageData) String() string {
+	return message.ID
+}
+
+// UnmarshalJSON unmarshals the message data from JSON
+//
+// Implements json.Unmarshaler
+func (message *MessageData) UnmarshalJSON(data []byte) error {
+	type surrogate MessageData
+	var inner struct {
+		surrogate
+		Timestamp      core.Time `json:"timestamp"`
+		ConversationID core.UUID `json:"conversationId"`
+	}
+	if err := json.Unmarshal(data, &inner); err != nil {
+		return errors.JSONUnmarshalError.Wrap(err)
+	}
+	*message = MessageData(inner.surrogate)
+	message.Timestamp = time.Time(inner.Timestamp)
+	message.ConversationID = uuid.UUID(inner.ConversationID)
+	return nil
+}
@@ -26,6 +26,7 @@ type NotificationChannel struct {
 	Client        *Client                `json:"-"`
 	Socket        *websocket.Conn        `json:"-"`
 	TopicReceived chan NotificationTopic `json:"-"`
+	Journal       NotificationJournal    `json:"-"` // if set, records every raw notification received on the websocket
 }
 
 // CreateNotificationChannel creates a new channel for notifications
@@ -206,22 +207,46 @@ func (channel *NotificationChannel) messageLoop() {
 			log.Errorf("Failed to read incoming message", err)
 			continue
 		}
-
-		topic, err := UnmarshalNotificationTopic(body)
-		if err != nil {
-			log.Warnf("%s, Body size: %d, Content: %s", err.Error(), len(body), string(body))
-			continue
-		}
-		switch topic.(type) {
-		case MetadataTopic:
-			if channel.LogHeartbeat {
-				log.Tracef("Request %d bytes: %s", len(body), string(body))
+		if channel.Journal != nil {
+			if err = channel.Journal.Record(NotificationJournalEntry{Time: time.Now().UTC(), Payload: body}); err != nil {
+				log.Errorf("Failed to record incoming message in the journal", err)
 			}
-		default:
+		}
+		_, _ = channel.dispatch(context.Background(), body)
+	}
+}
+
+// dispatch unmarshals a raw notification and sends the NotificationTopic to the TopicReceived chan
+//
+// Notifications that cannot be unmarshaled are logged and ignored (the returned topic is nil).
+func (channel *NotificationChannel) dispatch(context context.Context, body []byte) (NotificationTopic, error) {
+	log := channel.getLogger().Scope("receive")
+	topic, err := UnmarshalNotificationTopic(body)
+	if err != nil {
+		log.Warnf("%s, Body size: %d, Content: %s", err.Error(), len(body), string(body))
+		return nil, nil
+	}
+	switch topic.(type) {
+	case MetadataTopic:
+		if channel.LogHeartbeat {
 			log.Tracef("Request %d bytes: %s", len(body), string(body))
 		}
-		channel.TopicReceived <- topic
+	default:
+		log.Tracef("Request %d bytes: %s", len(body), string(body))
+	}
+	select {
+	case channel.TopicReceived <- topic:
+		return topic, nil
+	case <-context.Done():
+		return nil, context.Err()
+	}
+}
+
+func (channel *NotificationChannel) getLogger() *logger.Logger {
+	if channel.Logger == nil {
+		return logger.Create("gcloudcx", &logger.NilStream{})
 	}
+	return channel.Logger
 }
 
 // GetID gets the identifier of this
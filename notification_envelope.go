@@ -0,0 +1,165 @@
+package gcloudcx
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-errors"
+)
+
+// MaxNotificationEnvelopeSize is the maximum size of a request body accepted by NotificationChannel.WebhookHandler
+var MaxNotificationEnvelopeSize int64 = 10 * 1024 * 1024
+
+// UnmarshalNotificationEnvelope unmarshals notifications that were not delivered by a websocket into NotificationTopics
+//
+// The payload can be:
+//   - a notification, as received on the websocket ({"topicName": "...", "eventBody": {...}}),
+//   - an AWS EventBridge event, the notification is in its "detail" property,
+//   - an array (batch) of any of the above.
+//
+// The notifications that cannot be unmarshaled are skipped and their errors are returned along with the successful topics.
+func UnmarshalNotificationEnvelope(payload []byte) (topics []NotificationTopic, err error) {
+	notifications, err := unwrapNotificationEnvelope(payload)
+	if err != nil {
+		return []NotificationTopic{}, err
+	}
+	topics = make([]NotificationTopic, 0, len(notifications))
+	failures := []error{}
+	for _, notification := range notifications {
+		topic, err := UnmarshalNotificationTopic(notification)
+		if err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		topics = append(topics, topic)
+	}
+	return topics, errors.Join(failures...)
+}
+
+// unwrapNotificationEnvelope extracts the raw notifications from an envelope
+func unwrapNotificationEnvelope(payload []byte) (notifications []json.RawMessage, err error) {
+	payload = bytes.TrimSpace(payload)
+	if bytes.HasPrefix(payload, []byte("[")) {
+		var batch []json.RawMessage
+		if err = json.Unmarshal(payload, &batch); err != nil {
+			return nil, errors.JSONUnmarshalError.Wrap(err)
+		}
+		for _, item := range batch {
+			unwrapped, err := unwrapNotificationEnvelope(item)
+			if err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, unwrapped...)
+		}
+		return notifications, nil
+	}
+	var envelope struct {
+		TopicName string          `json:"topicName"`
+		Detail    json.RawMessage `json:"detail"`
+	}
+	if err = json.Unmarshal(payload, &envelope); err != nil {
+		return nil, errors.JSONUnmarshalError.Wrap(err)
+	}
+	if len(envelope.TopicName) > 0 {
+		return []json.RawMessage{payload}, nil
+	}
+	if len(envelope.Detail) > 0 {
+		return unwrapNotificationEnvelope(envelope.Detail)
+	}
+	return nil, errors.JSONUnmarshalError.Wrap(errors.ArgumentMissing.With("topicName"))
+}
+
+// WebhookAuthenticator authenticates the requests received by NotificationChannel.WebhookHandler
+//
+// It returns an error when the request does not come from a trusted sender.
+type WebhookAuthenticator func(request *http.Request) error
+
+// WebhookSharedSecret gets a WebhookAuthenticator that checks the given header of the requests carries the shared secret
+//
+// AWS EventBridge API destinations and most webhook senders can add a static header (API key) to their requests.
+// An empty secret rejects every request.
+func WebhookSharedSecret(header, secret string) WebhookAuthenticator {
+	return func(request *http.Request) error {
+		value := request.Header.Get(header)
+		if len(secret) == 0 || len(value) == 0 {
+			return errors.ArgumentMissing.With(header)
+		}
+		if subtle.ConstantTimeCompare([]byte(value), []byte(secret)) != 1 {
+			return errors.ArgumentInvalid.With(header)
+		}
+		return nil
+	}
+}
+
+// WebhookHandler gets an http.Handler that accepts notifications delivered by webhooks, AWS EventBridge, etc.
+//
+// Every request is authenticated with the given WebhookAuthenticator before its notifications are read,
+// requests that fail are answered with 401 Unauthorized. If authenticate is nil, all requests are rejected;
+// when the requests are already authenticated upstream, pass an authenticator that returns nil.
+//
+// The notifications are sent to the TopicReceived chan of this channel, exactly like the notifications received on the websocket.
+// If the channel has a Journal, the notifications are recorded as well.
+//
+// See UnmarshalNotificationEnvelope for the accepted payloads.
+func (channel *NotificationChannel) WebhookHandler(authenticate WebhookAuthenticator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log := channel.getLogger().Scope("webhook")
+
+		if r.Method != http.MethodPost {
+			core.RespondWithError(w, http.StatusMethodNotAllowed, errors.HTTPMethodNotAllowed.WithStack())
+			return
+		}
+		if authenticate == nil {
+			log.Errorf("No authenticator was given to the webhook handler, rejecting the request")
+			core.RespondWithError(w, http.StatusUnauthorized, errors.HTTPUnauthorized.WithStack())
+			return
+		}
+		if err := authenticate(r); err != nil {
+			log.Warnf("Rejected unauthenticated request from %s: %s", r.RemoteAddr, err)
+			core.RespondWithError(w, http.StatusUnauthorized, errors.HTTPUnauthorized.WithStack())
+			return
+		}
+		payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxNotificationEnvelopeSize))
+		if err != nil {
+			log.Errorf("Failed to read the request body", err)
+			core.RespondWithError(w, http.StatusBadRequest, errors.WithStack(err))
+			return
+		}
+		notifications, err := unwrapNotificationEnvelope(payload)
+		if err != nil {
+			log.Errorf("Failed to unwrap the notifications", err)
+			core.RespondWithError(w, http.StatusBadRequest, err)
+			return
+		}
+		dispatched := 0
+		for _, notification := range notifications {
+			if channel.Journal != nil {
+				if err = channel.Journal.Record(NotificationJournalEntry{Time: time.Now().UTC(), Payload: notification}); err != nil {
+					log.Errorf("Failed to record incoming notification in the journal", err)
+				}
+			}
+			topic, err := channel.dispatch(r.Context(), notification)
+			if err != nil {
+				log.Errorf("Failed to dispatch the notification", err)
+				core.RespondWithError(w, http.StatusServiceUnavailable, err)
+				return
+			}
+			if topic != nil {
+				dispatched++
+			}
+		}
+		log.Debugf("Dispatched %d/%d notifications", dispatched, len(notifications))
+		core.RespondWithJSON(w, http.StatusAccepted, struct {
+			Received   int `json:"received"`
+			Dispatched int `json:"dispatched"`
+		}{
+			Received:   len(notifications),
+			Dispatched: dispatched,
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package gcloudcx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// NotificationJournal describes a sink that records the raw notifications received by a NotificationChannel
+type NotificationJournal interface {
+	// Record records a raw notification
+	Record(entry NotificationJournalEntry) error
+}
+
+// NotificationJournalEntry describes a raw notification recorded in a NotificationJournal
+type NotificationJournalEntry struct {
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// NotificationJournalWriter records notifications as JSON lines in an io.Writer
+type NotificationJournalWriter struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewNotificationJournalWriter creates a new NotificationJournalWriter that writes in the given io.Writer
+func NewNotificationJournalWriter(writer io.Writer) *NotificationJournalWriter {
+	return &NotificationJournalWriter{writer: writer}
+}
+
+// NewNotificationJournalFile creates a new NotificationJournalWriter that appends to the given JSONL file
+//
+// The file is created if needed. The caller should Close the journal when done.
+func NewNotificationJournalFile(path string) (*NotificationJournalWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &NotificationJournalWriter{writer: file}, nil
+}
+
+// Record records a raw notification as a JSON line
+//
+// implements NotificationJournal
+func (journal *NotificationJournalWriter) Record(entry NotificationJournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.JSONMarshalError.Wrap(err)
+	}
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	if _, err = journal.writer.Write(append(data, '\n')); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Close closes the underlying io.Writer if it is an io.Closer
+func (journal *NotificationJournalWriter) Close() error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	if closer, ok := journal.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Replay replays a journal of raw notifications into the TopicReceived chan of this channel
+//
+// The notifications go through UnmarshalNotificationTopic like the ones received on the websocket.
+//
+// speed controls the delay between notifications: 1 replays at the original speed, 10 replays 10 times faster, 0 replays without any delay.
+//
+// Replay blocks until the journal is exhausted, the context is done, or an invalid entry is read.
+// Notifications that cannot be unmarshaled as a NotificationTopic are logged and skipped.
+func (channel *NotificationChannel) Replay(context context.Context, journal io.Reader, speed float64) (err error) {
+	log := channel.getLogger().Scope("replay")
+	scanner := bufio.NewScanner(journal)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var previous time.Time
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry NotificationJournalEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return errors.Join(errors.ArgumentInvalid.With("line", line), errors.JSONUnmarshalError.Wrap(err))
+		}
+		if speed > 0 && !previous.IsZero() && entry.Time.After(previous) {
+			delay := time.Duration(float64(entry.Time.Sub(previous)) / speed)
+			select {
+			case <-context.Done():
+				return context.Err()
+			case <-time.After(delay):
+			}
+		}
+		previous = entry.Time
+		if _, err = channel.dispatch(context, entry.Payload); err != nil {
+			return err
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return errors.WithStack(err)
+	}
+	log.Infof("Replayed the journal")
+	return nil
+}
@@ -80,21 +80,27 @@ func NotificationTopicFrom(topicName string) (NotificationTopic, error) {
 	return nil, errors.ArgumentInvalid.With("topicName", topicName)
 }
 
+// getTargets tells if the topicName matches the topicType and extracts its targets
+//
+// The topicName must match the topicType segment by segment (v2.users.{id}.conversations does not match v2.users.{id}.conversations.calls).
 func getTargets(topicType, topicName string) (found bool, targets []Identifiable) {
 	if topicType == topicName {
 		return true, targets
 	}
-	components := strings.Split(topicType, "{id}")
-	for _, component := range components {
-		if !strings.Contains(topicName, component) {
-			return false, []Identifiable{}
-		}
-		value := strings.TrimPrefix(topicName, component)
-		if index := strings.Index(value, "."); index > 0 {
-			value = value[:index]
+	typeSegments := strings.Split(topicType, ".")
+	nameSegments := strings.Split(topicName, ".")
+	if len(typeSegments) != len(nameSegments) {
+		return false, []Identifiable{}
+	}
+	for index, segment := range typeSegments {
+		if segment == "{id}" {
+			if id, err := uuid.Parse(nameSegments[index]); err == nil {
+				targets = append(targets, EntityRef{id})
+			}
+			continue
 		}
-		if id, err := uuid.Parse(value); err == nil {
-			targets = append(targets, EntityRef{id})
+		if segment != nameSegments[index] {
+			return false, []Identifiable{}
 		}
 	}
 	return true, targets
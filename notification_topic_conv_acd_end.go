@@ -21,11 +21,11 @@ type ConversationACDEndTopic struct {
 	QueueID        uuid.UUID
 	DivisionID     uuid.UUID
 	CorrelationID  string
-	DisconnectType string
+	DisconnectType DisconnectType
 	MediaType      string
 	MessageType    string
 	Provider       string
-	Direction      string
+	Direction      Direction
 	ANI            string
 	DNIS           string
 	AddressTo      string
@@ -82,11 +82,11 @@ func (topic *ConversationACDEndTopic) UnmarshalJSON(payload []byte) (err error)
 			DivisionID     uuid.UUID
 			ParticipantID  uuid.UUID
 			CorrelationID  string
-			DisconnectType string
+			DisconnectType DisconnectType
 			MediaType      string
 			MessageType    string
 			Provider       string
-			Direction      string
+			Direction      Direction
 			ANI            string
 			DNIS           string
 			AddressTo      string
@@ -2,7 +2,9 @@ package gcloudcx
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/gildas/go-core"
 	"github.com/gildas/go-errors"
 	"github.com/google/uuid"
 )
@@ -15,6 +17,16 @@ type ConversationACDStartTopic struct {
 	Name           string
 	ConversationID uuid.UUID
 	Participant    *Participant
+	Time           time.Time
+	SessionID      uuid.UUID
+	MediaType      string
+	Provider       string
+	Direction      Direction
+	ANI            string
+	DNIS           string
+	AddressTo      string
+	AddressFrom    string
+	QueueID        uuid.UUID
 	CorrelationID  string
 	Targets        []Identifiable
 }
@@ -57,8 +69,20 @@ func (topic *ConversationACDStartTopic) UnmarshalJSON(payload []byte) (err error
 	var inner struct {
 		TopicName string `json:"topicName"`
 		EventBody struct {
-			ID           uuid.UUID `json:"id,omitempty"`
-			Conversation EntityRef `json:"conversation,omitempty"`
+			ID             uuid.UUID      `json:"id,omitempty"`
+			Conversation   EntityRef      `json:"conversation,omitempty"`
+			ConversationID uuid.UUID      `json:"conversationId,omitempty"`
+			ParticipantID  uuid.UUID      `json:"participantId,omitempty"`
+			Time           core.Timestamp `json:"eventTime,omitempty"`
+			SessionID      uuid.UUID      `json:"sessionId,omitempty"`
+			MediaType      string         `json:"mediaType,omitempty"`
+			Provider       string         `json:"provider,omitempty"`
+			Direction      Direction      `json:"direction,omitempty"`
+			ANI            string         `json:"ani,omitempty"`
+			DNIS           string         `json:"dnis,omitempty"`
+			AddressTo      string         `json:"addressTo,omitempty"`
+			AddressFrom    string         `json:"addressFrom,omitempty"`
+			QueueID        uuid.UUID      `json:"queueId,omitempty"`
 		} `json:"eventBody"`
 		Metadata struct {
 			CorrelationID string `json:"correlationId,omitempty"`
@@ -70,7 +94,22 @@ func (topic *ConversationACDStartTopic) UnmarshalJSON(payload []byte) (err error
 		return errors.JSONUnmarshalError.Wrap(err)
 	}
 	topic.Name = inner.TopicName
-	topic.ConversationID = inner.EventBody.Conversation.ID
+	topic.ID = inner.EventBody.ID
+	topic.ConversationID = inner.EventBody.ConversationID
+	if topic.ConversationID == uuid.Nil {
+		topic.ConversationID = inner.EventBody.Conversation.ID
+	}
+	topic.Participant = &Participant{ID: inner.EventBody.ParticipantID}
+	topic.Time = time.Time(inner.EventBody.Time)
+	topic.SessionID = inner.EventBody.SessionID
+	topic.MediaType = inner.EventBody.MediaType
+	topic.Provider = inner.EventBody.Provider
+	topic.Direction = inner.EventBody.Direction
+	topic.ANI = inner.EventBody.ANI
+	topic.DNIS = inner.EventBody.DNIS
+	topic.AddressTo = inner.EventBody.AddressTo
+	topic.AddressFrom = inner.EventBody.AddressFrom
+	topic.QueueID = inner.EventBody.QueueID
 	topic.CorrelationID = inner.Metadata.CorrelationID
 	return
 }
@@ -2,7 +2,9 @@ package gcloudcx
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/gildas/go-core"
 	"github.com/gildas/go-errors"
 	"github.com/google/uuid"
 )
@@ -11,11 +13,23 @@ import (
 //
 // See: https://developer.genesys.cloud/notificationsalerts/notifications/available-topics#v2-detail-events-conversation--id--customer-start
 type ConversationCustomerStartTopic struct {
-	ID             uuid.UUID
-	Name           string
-	ConversationID uuid.UUID
-	CorrelationID  string
-	Targets        []Identifiable
+	ID                     uuid.UUID
+	Name                   string
+	ConversationID         uuid.UUID
+	Participant            *Participant
+	Time                   time.Time
+	SessionID              uuid.UUID
+	MediaType              string
+	Provider               string
+	Direction              Direction
+	ANI                    string
+	DNIS                   string
+	AddressTo              string
+	AddressFrom            string
+	ExternalContactID      string
+	ExternalOrganizationID string
+	CorrelationID          string
+	Targets                []Identifiable
 }
 
 func init() {
@@ -26,7 +40,7 @@ func init() {
 //
 // implements core.TypeCarrier
 func (topic ConversationCustomerStartTopic) GetType() string {
-	return "v2.detail.events.conversation.{id}.acd.end"
+	return "v2.detail.events.conversation.{id}.customer.start"
 }
 
 // GetTargets returns the targets
@@ -56,8 +70,21 @@ func (topic *ConversationCustomerStartTopic) UnmarshalJSON(payload []byte) (err
 	var inner struct {
 		TopicName string `json:"topicName"`
 		EventBody struct {
-			ID           uuid.UUID `json:"id,omitempty"`
-			Conversation EntityRef `json:"conversation,omitempty"`
+			ID                     uuid.UUID      `json:"id,omitempty"`
+			Conversation           EntityRef      `json:"conversation,omitempty"`
+			ConversationID         uuid.UUID      `json:"conversationId,omitempty"`
+			ParticipantID          uuid.UUID      `json:"participantId,omitempty"`
+			Time                   core.Timestamp `json:"eventTime,omitempty"`
+			SessionID              uuid.UUID      `json:"sessionId,omitempty"`
+			MediaType              string         `json:"mediaType,omitempty"`
+			Provider               string         `json:"provider,omitempty"`
+			Direction              Direction      `json:"direction,omitempty"`
+			ANI                    string         `json:"ani,omitempty"`
+			DNIS                   string         `json:"dnis,omitempty"`
+			AddressTo              string         `json:"addressTo,omitempty"`
+			AddressFrom            string         `json:"addressFrom,omitempty"`
+			ExternalContactID      string         `json:"externalContactId,omitempty"`
+			ExternalOrganizationID string         `json:"externalOrganizationId,omitempty"`
 		} `json:"eventBody"`
 		Metadata struct {
 			CorrelationID string `json:"correlationId,omitempty"`
@@ -69,7 +96,23 @@ func (topic *ConversationCustomerStartTopic) UnmarshalJSON(payload []byte) (err
 		return errors.JSONUnmarshalError.Wrap(err)
 	}
 	topic.Name = inner.TopicName
-	topic.ConversationID = inner.EventBody.Conversation.ID
+	topic.ID = inner.EventBody.ID
+	topic.ConversationID = inner.EventBody.ConversationID
+	if topic.ConversationID == uuid.Nil {
+		topic.ConversationID = inner.EventBody.Conversation.ID
+	}
+	topic.Participant = &Participant{ID: inner.EventBody.ParticipantID}
+	topic.Time = time.Time(inner.EventBody.Time)
+	topic.SessionID = inner.EventBody.SessionID
+	topic.MediaType = inner.EventBody.MediaType
+	topic.Provider = inner.EventBody.Provider
+	topic.Direction = inner.EventBody.Direction
+	topic.ANI = inner.EventBody.ANI
+	topic.DNIS = inner.EventBody.DNIS
+	topic.AddressTo = inner.EventBody.AddressTo
+	topic.AddressFrom = inner.EventBody.AddressFrom
+	topic.ExternalContactID = inner.EventBody.ExternalContactID
+	topic.ExternalOrganizationID = inner.EventBody.ExternalOrganizationID
 	topic.CorrelationID = inner.Metadata.CorrelationID
 	return
 }
@@ -2,7 +2,9 @@ package gcloudcx
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/gildas/go-core"
 	"github.com/gildas/go-errors"
 	"github.com/google/uuid"
 )
@@ -14,6 +16,20 @@ type ConversationFlowStartTopic struct {
 	ID             uuid.UUID
 	Name           string
 	ConversationID uuid.UUID
+	Participant    *Participant
+	Time           time.Time
+	SessionID      uuid.UUID
+	MediaType      string
+	Provider       string
+	Direction      Direction
+	ANI            string
+	DNIS           string
+	AddressTo      string
+	AddressFrom    string
+	FlowID         uuid.UUID
+	FlowType       string
+	FlowVersion    string
+	DivisionID     uuid.UUID
 	CorrelationID  string
 	Targets        []Identifiable
 }
@@ -26,7 +42,7 @@ func init() {
 //
 // implements core.TypeCarrier
 func (topic ConversationFlowStartTopic) GetType() string {
-	return "v2.detail.events.conversation.{id}.acd.end"
+	return "v2.detail.events.conversation.{id}.flow.start"
 }
 
 // GetTargets returns the targets
@@ -56,8 +72,23 @@ func (topic *ConversationFlowStartTopic) UnmarshalJSON(payload []byte) (err erro
 	var inner struct {
 		TopicName string `json:"topicName"`
 		EventBody struct {
-			ID           uuid.UUID `json:"id,omitempty"`
-			Conversation EntityRef `json:"conversation,omitempty"`
+			ID             uuid.UUID      `json:"id,omitempty"`
+			Conversation   EntityRef      `json:"conversation,omitempty"`
+			ConversationID uuid.UUID      `json:"conversationId,omitempty"`
+			ParticipantID  uuid.UUID      `json:"participantId,omitempty"`
+			Time           core.Timestamp `json:"eventTime,omitempty"`
+			SessionID      uuid.UUID      `json:"sessionId,omitempty"`
+			MediaType      string         `json:"mediaType,omitempty"`
+			Provider       string         `json:"provider,omitempty"`
+			Direction      Direction      `json:"direction,omitempty"`
+			ANI            string         `json:"ani,omitempty"`
+			DNIS           string         `json:"dnis,omitempty"`
+			AddressTo      string         `json:"addressTo,omitempty"`
+			AddressFrom    string         `json:"addressFrom,omitempty"`
+			FlowID         uuid.UUID      `json:"flowId,omitempty"`
+			FlowType       string         `json:"flowType,omitempty"`
+			FlowVersion    string         `json:"flowVersion,omitempty"`
+			DivisionID     uuid.UUID      `json:"divisionId,omitempty"`
 		} `json:"eventBody"`
 		Metadata struct {
 			CorrelationID string `json:"correlationId,omitempty"`
@@ -69,7 +100,25 @@ func (topic *ConversationFlowStartTopic) UnmarshalJSON(payload []byte) (err erro
 		return errors.JSONUnmarshalError.Wrap(err)
 	}
 	topic.Name = inner.TopicName
-	topic.ConversationID = inner.EventBody.Conversation.ID
+	topic.ID = inner.EventBody.ID
+	topic.ConversationID = inner.EventBody.ConversationID
+	if topic.ConversationID == uuid.Nil {
+		topic.ConversationID = inner.EventBody.Conversation.ID
+	}
+	topic.Participant = &Participant{ID: inner.EventBody.ParticipantID}
+	topic.Time = time.Time(inner.EventBody.Time)
+	topic.SessionID = inner.EventBody.SessionID
+	topic.MediaType = inner.EventBody.MediaType
+	topic.Provider = inner.EventBody.Provider
+	topic.Direction = inner.EventBody.Direction
+	topic.ANI = inner.EventBody.ANI
+	topic.DNIS = inner.EventBody.DNIS
+	topic.AddressTo = inner.EventBody.AddressTo
+	topic.AddressFrom = inner.EventBody.AddressFrom
+	topic.FlowID = inner.EventBody.FlowID
+	topic.FlowType = inner.EventBody.FlowType
+	topic.FlowVersion = inner.EventBody.FlowVersion
+	topic.DivisionID = inner.EventBody.DivisionID
 	topic.CorrelationID = inner.Metadata.CorrelationID
 	return
 }
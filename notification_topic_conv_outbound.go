@@ -26,7 +26,7 @@ func init() {
 //
 // implements core.TypeCarrier
 func (topic ConversationOutboundTopic) GetType() string {
-	return "v2.detail.events.conversation.{id}.acd.end"
+	return "v2.detail.events.conversation.{id}.outbound"
 }
 
 // GetTargets returns the targets
@@ -26,7 +26,7 @@ func init() {
 //
 // implements core.TypeCarrier
 func (topic ConversationVoicemailStartTopic) GetType() string {
-	return "v2.detail.events.conversation.{id}.acd.end"
+	return "v2.detail.events.conversation.{id}.voicemail.start"
 }
 
 // GetTargets returns the targets
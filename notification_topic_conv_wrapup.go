@@ -2,7 +2,9 @@ package gcloudcx
 
 import (
 	"encoding/json"
+	"time"
 
+	"github.com/gildas/go-core"
 	"github.com/gildas/go-errors"
 	"github.com/google/uuid"
 )
@@ -14,6 +16,21 @@ type ConversationWrapupTopic struct {
 	ID             uuid.UUID
 	Name           string
 	ConversationID uuid.UUID
+	Participant    *Participant
+	Time           time.Time
+	SessionID      uuid.UUID
+	MediaType      string
+	Provider       string
+	Direction      Direction
+	ANI            string
+	DNIS           string
+	AddressTo      string
+	AddressFrom    string
+	QueueID        uuid.UUID
+	UserID         uuid.UUID
+	WrapupCode     string
+	WrapupNotes    string
+	WrapupDuration time.Duration
 	CorrelationID  string
 	Targets        []Identifiable
 }
@@ -26,7 +43,7 @@ func init() {
 //
 // implements core.TypeCarrier
 func (topic ConversationWrapupTopic) GetType() string {
-	return "v2.detail.events.conversation.{id}.acd.end"
+	return "v2.detail.events.conversation.{id}.wrapup"
 }
 
 // GetTargets returns the targets
@@ -56,8 +73,24 @@ func (topic *ConversationWrapupTopic) UnmarshalJSON(payload []byte) (err error)
 	var inner struct {
 		TopicName string `json:"topicName"`
 		EventBody struct {
-			ID           uuid.UUID `json:"id,omitempty"`
-			Conversation EntityRef `json:"conversation,omitempty"`
+			ID               uuid.UUID      `json:"id,omitempty"`
+			Conversation     EntityRef      `json:"conversation,omitempty"`
+			ConversationID   uuid.UUID      `json:"conversationId,omitempty"`
+			ParticipantID    uuid.UUID      `json:"participantId,omitempty"`
+			Time             core.Timestamp `json:"eventTime,omitempty"`
+			SessionID        uuid.UUID      `json:"sessionId,omitempty"`
+			MediaType        string         `json:"mediaType,omitempty"`
+			Provider         string         `json:"provider,omitempty"`
+			Direction        Direction      `json:"direction,omitempty"`
+			ANI              string         `json:"ani,omitempty"`
+			DNIS             string         `json:"dnis,omitempty"`
+			AddressTo        string         `json:"addressTo,omitempty"`
+			AddressFrom      string         `json:"addressFrom,omitempty"`
+			QueueID          uuid.UUID      `json:"queueId,omitempty"`
+			UserID           uuid.UUID      `json:"userId,omitempty"`
+			WrapupCode       string         `json:"wrapupCode,omitempty"`
+			WrapupNotes      string         `json:"wrapupNotes,omitempty"`
+			WrapupDurationMs int64          `json:"wrapupDurationMs,omitempty"`
 		} `json:"eventBody"`
 		Metadata struct {
 			CorrelationID string `json:"correlationId,omitempty"`
@@ -69,7 +102,26 @@ func (topic *ConversationWrapupTopic) UnmarshalJSON(payload []byte) (err error)
 		return errors.JSONUnmarshalError.Wrap(err)
 	}
 	topic.Name = inner.TopicName
-	topic.ConversationID = inner.EventBody.Conversation.ID
+	topic.ID = inner.EventBody.ID
+	topic.ConversationID = inner.EventBody.ConversationID
+	if topic.ConversationID == uuid.Nil {
+		topic.ConversationID = inner.EventBody.Conversation.ID
+	}
+	topic.Participant = &Participant{ID: inner.EventBody.ParticipantID}
+	topic.Time = time.Time(inner.EventBody.Time)
+	topic.SessionID = inner.EventBody.SessionID
+	topic.MediaType = inner.EventBody.MediaType
+	topic.Provider = inner.EventBody.Provider
+	topic.Direction = inner.EventBody.Direction
+	topic.ANI = inner.EventBody.ANI
+	topic.DNIS = inner.EventBody.DNIS
+	topic.AddressTo = inner.EventBody.AddressTo
+	topic.AddressFrom = inner.EventBody.AddressFrom
+	topic.QueueID = inner.EventBody.QueueID
+	topic.UserID = inner.EventBody.UserID
+	topic.WrapupCode = inner.EventBody.WrapupCode
+	topic.WrapupNotes = inner.EventBody.WrapupNotes
+	topic.WrapupDuration = time.Duration(inner.EventBody.WrapupDurationMs) * time.Millisecond
 	topic.CorrelationID = inner.Metadata.CorrelationID
 	return
 }
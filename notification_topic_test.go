@@ -1,8 +1,12 @@
 package gcloudcx_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -10,7 +14,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gildas/go-errors"
 	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/suite"
 
@@ -92,3 +98,265 @@ func (suite *NotificationTopicSuite) TestCanUnmarshalConverstionMessageTopic() {
 	suite.Require().Truef(ok, "Expected a ConversationChatMessageTopic, got %T", topic)
 	suite.Require().NotNil(actual, "Cast Notification Topic returned nil")
 }
+
+func (suite *NotificationTopicSuite) TestCanUnmarshalUserConversationCallTopic() {
+	payload := suite.LoadTestData("notification_topic_user_conversation_call.json")
+
+	topic, err := gcloudcx.UnmarshalNotificationTopic(payload)
+	suite.Require().NoErrorf(err, "Failed to Unmarshal Notification Topic. %s", err)
+	suite.Require().NotNil(topic, "Unmarshal Notification Topic returned nil")
+
+	actual, ok := topic.(gcloudcx.UserConversationCallTopic)
+	suite.Require().Truef(ok, "Expected a UserConversationCallTopic, got %T", topic)
+	suite.Assert().Equal("6408f799-973a-436a-9e1a-a75a6ddc46f5", actual.User.ID.String())
+	suite.Assert().Equal("aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e", actual.ConversationID.String())
+	suite.Assert().Equal("ca138a93-3198-43b4-8ef4-bced8d38b3b9", actual.CorrelationID)
+	suite.Require().Len(actual.Participants, 2)
+	suite.Assert().Equal(gcloudcx.ParticipantPurposeCustomer, actual.Participants[0].Purpose)
+	suite.Assert().Equal("12345", actual.Participants[0].Attributes["accountNumber"])
+	suite.Require().Len(actual.Participants[0].Calls, 1)
+	suite.Assert().Equal(gcloudcx.ConversationStateConnected, actual.Participants[0].Calls[0].State)
+	suite.Assert().True(actual.Participants[0].Calls[0].Recording)
+	suite.Assert().Equal(gcloudcx.ParticipantPurposeAgent, actual.Participants[1].Purpose)
+	suite.Require().NotNil(actual.Participants[1].User)
+	suite.Assert().Equal("6408f799-973a-436a-9e1a-a75a6ddc46f5", actual.Participants[1].User.ID.String())
+	suite.Require().Len(actual.Participants[1].Calls, 1)
+	suite.Assert().Equal(gcloudcx.ConversationStateAlerting, actual.Participants[1].Calls[0].State)
+}
+
+func (suite *NotificationTopicSuite) TestCanUnmarshalUserConversationMessageTopic() {
+	payload := suite.LoadTestData("notification_topic_user_conversation_message.json")
+
+	topic, err := gcloudcx.UnmarshalNotificationTopic(payload)
+	suite.Require().NoErrorf(err, "Failed to Unmarshal Notification Topic. %s", err)
+	suite.Require().NotNil(topic, "Unmarshal Notification Topic returned nil")
+
+	actual, ok := topic.(gcloudcx.UserConversationMessageTopic)
+	suite.Require().Truef(ok, "Expected a UserConversationMessageTopic, got %T", topic)
+	suite.Assert().Equal("6408f799-973a-436a-9e1a-a75a6ddc46f5", actual.User.ID.String())
+	suite.Assert().Equal("aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e", actual.ConversationID.String())
+	suite.Require().Len(actual.Participants, 2)
+	suite.Require().Len(actual.Participants[0].Messages, 1)
+	suite.Assert().Equal("open", actual.Participants[0].Messages[0].Type)
+	suite.Assert().Equal(gcloudcx.ConversationStateConnected, actual.Participants[0].Messages[0].State)
+	suite.Require().Len(actual.Participants[0].Messages[0].Messages, 1)
+	suite.Assert().Equal("received", actual.Participants[0].Messages[0].Messages[0].Status)
+	suite.Require().Len(actual.Participants[1].Messages, 1)
+}
+
+func (suite *NotificationTopicSuite) TestCanBuildUserConversationTopics() {
+	userID := uuid.MustParse("6408f799-973a-436a-9e1a-a75a6ddc46f5")
+	expected := map[string]gcloudcx.NotificationTopic{
+		"calls":     gcloudcx.UserConversationCallTopic{},
+		"callbacks": gcloudcx.UserConversationCallbackTopic{},
+		"chats":     gcloudcx.UserConversationChatTopic{},
+		"emails":    gcloudcx.UserConversationEmailTopic{},
+		"messages":  gcloudcx.UserConversationMessageTopic{},
+		"videos":    gcloudcx.UserConversationVideoTopic{},
+	}
+	for media, expectedTopic := range expected {
+		topicName := fmt.Sprintf("v2.users.%s.conversations.%s", userID, media)
+		topic, err := gcloudcx.NotificationTopicFrom(topicName)
+		suite.Require().NoErrorf(err, "Failed to build topic %s. %s", topicName, err)
+		suite.Assert().IsTypef(expectedTopic, topic, "Wrong type for topic %s", topicName)
+		suite.Assert().Equal(topicName, topic.String())
+		suite.Assert().Equal(topicName, expectedTopic.With(gcloudcx.User{ID: userID}).String())
+	}
+}
+
+func (suite *NotificationTopicSuite) TestCanJournalAndReplay() {
+	var buffer bytes.Buffer
+	journal := gcloudcx.NewNotificationJournalWriter(&buffer)
+	start := time.Now().UTC()
+	for index, filename := range []string{"notification_topic_chat_message.json", "notification_topic_user_conversation_call.json", "notification_topic_user_conversation_message.json"} {
+		payload := new(bytes.Buffer)
+		suite.Require().NoError(json.Compact(payload, suite.LoadTestData(filename)))
+		err := journal.Record(gcloudcx.NotificationJournalEntry{Time: start.Add(time.Duration(index) * 100 * time.Millisecond), Payload: payload.Bytes()})
+		suite.Require().NoErrorf(err, "Failed to record in the journal. %s", err)
+	}
+	suite.Require().Equal(3, strings.Count(buffer.String(), "\n"), "The journal should contain 3 lines")
+
+	channel := &gcloudcx.NotificationChannel{TopicReceived: make(chan gcloudcx.NotificationTopic), Logger: suite.Logger}
+	received := []gcloudcx.NotificationTopic{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for topic := range channel.TopicReceived {
+			received = append(received, topic)
+		}
+	}()
+
+	replayStart := time.Now()
+	err := channel.Replay(context.Background(), bytes.NewReader(buffer.Bytes()), 2)
+	suite.Require().NoErrorf(err, "Failed to replay the journal. %s", err)
+	suite.Assert().GreaterOrEqual(time.Since(replayStart), 100*time.Millisecond, "The replay should take about half of the original 200ms")
+	close(channel.TopicReceived)
+	<-done
+
+	suite.Require().Len(received, 3)
+	suite.Assert().IsType(gcloudcx.ConversationChatMessageTopic{}, received[0])
+	suite.Assert().IsType(gcloudcx.UserConversationCallTopic{}, received[1])
+	suite.Assert().IsType(gcloudcx.UserConversationMessageTopic{}, received[2])
+}
+
+func (suite *NotificationTopicSuite) TestCanStopReplayWithContext() {
+	payload := new(bytes.Buffer)
+	suite.Require().NoError(json.Compact(payload, suite.LoadTestData("notification_topic_chat_message.json")))
+	entry, err := json.Marshal(gcloudcx.NotificationJournalEntry{Time: time.Now(), Payload: payload.Bytes()})
+	suite.Require().NoError(err)
+
+	channel := &gcloudcx.NotificationChannel{TopicReceived: make(chan gcloudcx.NotificationTopic)}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = channel.Replay(ctx, bytes.NewReader(append(entry, '\n')), 0) // nobody reads TopicReceived
+	suite.Assert().ErrorIs(err, context.DeadlineExceeded)
+
+	err = channel.Replay(context.Background(), strings.NewReader("not json\n"), 0)
+	suite.Assert().Error(err, "Replaying an invalid journal should fail")
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(err, errors.JSONUnmarshalError)
+	var syntaxError *json.SyntaxError
+	suite.Assert().ErrorAs(err, &syntaxError, "The cause of the failure should be kept")
+}
+
+func (suite *NotificationTopicSuite) TestCanUnmarshalEventBridgeEnvelope() {
+	payload := suite.LoadTestData("notification_eventbridge_user_presence.json")
+
+	topics, err := gcloudcx.UnmarshalNotificationEnvelope(payload)
+	suite.Require().NoErrorf(err, "Failed to Unmarshal Notification Envelope. %s", err)
+	suite.Require().Len(topics, 1)
+	actual, ok := topics[0].(gcloudcx.UserPresenceTopic)
+	suite.Require().Truef(ok, "Expected a UserPresenceTopic, got %T", topics[0])
+	suite.Assert().Equal("6408f799-973a-436a-9e1a-a75a6ddc46f5", actual.User.ID.String())
+}
+
+func (suite *NotificationTopicSuite) TestCanUnmarshalBatchedEnvelope() {
+	payload := fmt.Sprintf(`[%s, %s, {"topicName": "v2.unknown.topic", "eventBody": {}}]`,
+		suite.LoadTestData("notification_eventbridge_user_presence.json"),
+		suite.LoadTestData("notification_topic_user_conversation_call.json"),
+	)
+
+	topics, err := gcloudcx.UnmarshalNotificationEnvelope([]byte(payload))
+	suite.Assert().Error(err, "The unknown topic should be reported")
+	suite.Require().Len(topics, 2)
+	suite.Assert().IsType(gcloudcx.UserPresenceTopic{}, topics[0])
+	suite.Assert().IsType(gcloudcx.UserConversationCallTopic{}, topics[1])
+
+	_, err = gcloudcx.UnmarshalNotificationEnvelope([]byte(`{"detail-type": "nothing"}`))
+	suite.Assert().Error(err, "An envelope without notification should fail")
+}
+
+func (suite *NotificationTopicSuite) TestCanReceiveNotificationsWithWebhook() {
+	var journal bytes.Buffer
+	channel := &gcloudcx.NotificationChannel{
+		TopicReceived: make(chan gcloudcx.NotificationTopic),
+		Logger:        suite.Logger,
+		Journal:       gcloudcx.NewNotificationJournalWriter(&journal),
+	}
+	received := []gcloudcx.NotificationTopic{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for topic := range channel.TopicReceived {
+			received = append(received, topic)
+		}
+	}()
+	server := httptest.NewServer(channel.WebhookHandler(gcloudcx.WebhookSharedSecret("X-Api-Key", "s3cr3t")))
+	defer server.Close()
+
+	payload := fmt.Sprintf(`[%s, %s]`,
+		suite.LoadTestData("notification_eventbridge_user_presence.json"),
+		suite.LoadTestData("notification_topic_chat_message.json"),
+	)
+	post := func(key, body string) (*http.Response, error) {
+		request, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		if len(key) > 0 {
+			request.Header.Set("X-Api-Key", key)
+		}
+		return http.DefaultClient.Do(request)
+	}
+	response, err := post("s3cr3t", payload)
+	suite.Require().NoErrorf(err, "Failed to post notifications. %s", err)
+	defer response.Body.Close()
+	suite.Assert().Equal(http.StatusAccepted, response.StatusCode)
+
+	response, err = post("", payload)
+	suite.Require().NoErrorf(err, "Failed to post notifications. %s", err)
+	defer response.Body.Close()
+	suite.Assert().Equal(http.StatusUnauthorized, response.StatusCode, "Requests without the shared secret should be rejected")
+
+	response, err = post("wrong", payload)
+	suite.Require().NoErrorf(err, "Failed to post notifications. %s", err)
+	defer response.Body.Close()
+	suite.Assert().Equal(http.StatusUnauthorized, response.StatusCode, "Requests with a wrong shared secret should be rejected")
+
+	response, err = post("s3cr3t", "not json")
+	suite.Require().NoErrorf(err, "Failed to post notifications. %s", err)
+	defer response.Body.Close()
+	suite.Assert().Equal(http.StatusBadRequest, response.StatusCode)
+
+	response, err = http.Get(server.URL)
+	suite.Require().NoErrorf(err, "Failed to get. %s", err)
+	defer response.Body.Close()
+	suite.Assert().Equal(http.StatusMethodNotAllowed, response.StatusCode)
+
+	close(channel.TopicReceived)
+	<-done
+	suite.Require().Len(received, 2)
+	suite.Assert().IsType(gcloudcx.UserPresenceTopic{}, received[0])
+	suite.Assert().IsType(gcloudcx.ConversationChatMessageTopic{}, received[1])
+	suite.Assert().Equal(2, strings.Count(journal.String(), "\n"), "The journal should contain 2 lines")
+}
+
+func (suite *NotificationTopicSuite) TestShouldRejectWebhookWithoutAuthenticator() {
+	channel := &gcloudcx.NotificationChannel{TopicReceived: make(chan gcloudcx.NotificationTopic, 1), Logger: suite.Logger}
+	server := httptest.NewServer(channel.WebhookHandler(nil))
+	defer server.Close()
+
+	response, err := http.Post(server.URL, "application/json", bytes.NewReader(suite.LoadTestData("notification_topic_chat_message.json")))
+	suite.Require().NoErrorf(err, "Failed to post notifications. %s", err)
+	defer response.Body.Close()
+	suite.Assert().Equal(http.StatusUnauthorized, response.StatusCode)
+	suite.Assert().Empty(channel.TopicReceived, "No topic should be dispatched")
+}
+
+func (suite *NotificationTopicSuite) TestCanCheckTopics() {
+	subject := gcloudcx.AuthorizationSubject{}
+	err := suite.UnmarshalData("authorization-subject.json", &subject)
+	suite.Require().NoErrorf(err, "Failed to load authorization subject, Error: %s", err)
+	subject.Initialize(suite.Logger)
+	definitions := []gcloudcx.NotificationTopicDefinition{
+		{ID: "v2.users.{id}.presence"},
+		{ID: "v2.users.{id}.conversations.calls", Permissions: []string{"conversation:call:monitor", "routing:language:assign"}},
+		{ID: "v2.users.{id}.conversations.emails", Permissions: []string{"conversation:email:accept", "processing:space:deploy"}},
+	}
+	user := gcloudcx.User{ID: uuid.New()}
+	validations := subject.CheckTopics(
+		definitions,
+		gcloudcx.UserPresenceTopic{}.With(user),
+		gcloudcx.UserConversationCallTopic{}.With(user),
+		gcloudcx.UserConversationEmailTopic{}.With(user),
+		gcloudcx.UserConversationVideoTopic{}.With(user),
+	)
+	suite.Require().Len(validations, 4)
+	suite.Assert().True(validations[0].Available)
+	suite.Assert().True(validations[0].Allowed)
+	suite.Assert().Empty(validations[0].MissingPermissions)
+	suite.Assert().True(validations[1].Allowed)
+	suite.Assert().Len(validations[1].RequiredPermissions, 2)
+	suite.Assert().True(validations[2].Available)
+	suite.Assert().False(validations[2].Allowed)
+	suite.Assert().Equal([]string{"processing:space:deploy"}, validations[2].MissingPermissions)
+	suite.Assert().False(validations[3].Available)
+	suite.Assert().False(validations[3].Allowed)
+}
+
+func (suite *NotificationTopicSuite) TestShouldNotValidateTopicsWithoutGrantID() {
+	client := gcloudcx.NewClient(&gcloudcx.ClientOptions{Region: "mypurecloud.com", Logger: suite.Logger}).
+		SetAuthorizationGrant(&gcloudcx.ClientCredentialsGrant{})
+	channel := &gcloudcx.NotificationChannel{Client: client}
+	_, _, err := channel.ValidateTopics(context.Background(), gcloudcx.UserPresenceTopic{}.With(gcloudcx.User{ID: uuid.New()}))
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+}
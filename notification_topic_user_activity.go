@@ -53,15 +53,6 @@ func (topic UserActivityTopic) String() string {
 
 // UnmarshalJSON unmarshals JSON into this
 func (topic *UserActivityTopic) UnmarshalJSON(payload []byte) (err error) {
-	// TODO: Put this schema:
-	/*
-		{
-		  "activeQueueIds": [
-		    {}
-		  ],
-		  "dateActiveQueuesChanged": "string"
-		}
-	*/
 	var inner struct {
 		TopicName string `json:"topicName"`
 		EventBody struct {
@@ -69,7 +60,7 @@ func (topic *UserActivityTopic) UnmarshalJSON(payload []byte) (err error) {
 			RoutingStatus           *RoutingStatus `json:"routingStatus"`
 			Presence                *UserPresence  `json:"presence"`
 			OutOfOffice             *OutOfOffice   `json:"outOfOffice"`
-			ActiveQueueIDs          []string       `json:"activeQueueIds"` // TODO: Not sure about this (the doc says: "activeQueueIds": [{}])
+			ActiveQueueIDs          []string       `json:"activeQueueIds"`
 			DateActiveQueuesChanged string         `json:"dateActiveQueuesChanged"`
 		}
 		Metadata struct {
@@ -88,6 +79,12 @@ func (topic *UserActivityTopic) UnmarshalJSON(payload []byte) (err error) {
 	topic.User = &User{ID: targets[0].GetID()}
 	topic.Presence = inner.EventBody.Presence
 	topic.RoutingStatus = inner.EventBody.RoutingStatus
+	topic.ActiveQueues = make([]*Queue, 0, len(inner.EventBody.ActiveQueueIDs))
+	for _, value := range inner.EventBody.ActiveQueueIDs {
+		if queueID, err := uuid.Parse(value); err == nil {
+			topic.ActiveQueues = append(topic.ActiveQueues, &Queue{ID: queueID})
+		}
+	}
 	topic.CorrelationID = inner.Metadata.CorrelationID
 	return
 }
@@ -0,0 +1,60 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// userConversationEvent is the content shared by the user conversation topics (calls, callbacks, emails, ...)
+type userConversationEvent struct {
+	Name           string
+	User           *User
+	ConversationID uuid.UUID
+	Participants   []*Participant
+	CorrelationID  string
+}
+
+// unmarshalUserConversationEvent unmarshals the payload of a user conversation topic of the given type
+//
+// The event body flattens the media properties (call, email, ...) into each participant,
+// so each participant is decoded as a Participant and as the media M, then attach adds the media to the participant.
+func unmarshalUserConversationEvent[M any](topicType string, payload []byte, attach func(participant *Participant, media *M)) (event userConversationEvent, err error) {
+	var inner struct {
+		TopicName string `json:"topicName"`
+		EventBody struct {
+			ConversationID uuid.UUID         `json:"id"`
+			Name           string            `json:"name"`
+			Participants   []json.RawMessage `json:"participants"`
+		} `json:"eventBody"`
+		Metadata struct {
+			CorrelationID string `json:"correlationId,omitempty"`
+		} `json:"metadata,omitempty"`
+	}
+	if err = json.Unmarshal(payload, &inner); err != nil {
+		return event, errors.JSONUnmarshalError.Wrap(err)
+	}
+	found, targets := getTargets(topicType, inner.TopicName)
+	if !found || len(targets) == 0 {
+		return event, errors.JSONUnmarshalError.Wrap(errors.ArgumentInvalid.With("topicName", inner.TopicName))
+	}
+	event.Name = inner.TopicName
+	event.User = &User{ID: targets[0].GetID()}
+	event.ConversationID = inner.EventBody.ConversationID
+	event.CorrelationID = inner.Metadata.CorrelationID
+	event.Participants = make([]*Participant, 0, len(inner.EventBody.Participants))
+	for _, raw := range inner.EventBody.Participants {
+		var participant Participant
+		if err = json.Unmarshal(raw, &participant); err != nil {
+			return event, errors.JSONUnmarshalError.Wrap(err)
+		}
+		var media M
+		if err = json.Unmarshal(raw, &media); err != nil {
+			return event, errors.JSONUnmarshalError.Wrap(err)
+		}
+		attach(&participant, &media)
+		event.Participants = append(event.Participants, &participant)
+	}
+	return event, nil
+}
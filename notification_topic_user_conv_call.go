@@ -0,0 +1,68 @@
+package gcloudcx
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserConversationCallTopic describes a Topic about User's Calls
+//
+// Each Participant carries its call media in Participant.Calls.
+//
+// See: https://developer.genesys.cloud/notificationsalerts/notifications/available-topics#v2-users--id--conversations-calls
+type UserConversationCallTopic struct {
+	Name           string
+	User           *User
+	ConversationID uuid.UUID
+	Participants   []*Participant
+	CorrelationID  string
+	Targets        []Identifiable
+}
+
+func init() {
+	notificationTopicRegistry.Add(UserConversationCallTopic{})
+}
+
+// GetType returns the type of this topic
+//
+// implements core.TypeCarrier
+func (topic UserConversationCallTopic) GetType() string {
+	return "v2.users.{id}.conversations.calls"
+}
+
+// GetTargets returns the targets of this topic
+func (topic UserConversationCallTopic) GetTargets() []Identifiable {
+	return topic.Targets
+}
+
+// With creates a new NotificationTopic with the given targets
+func (topic UserConversationCallTopic) With(targets ...Identifiable) NotificationTopic {
+	newTopic := topic
+	newTopic.Targets = targets
+	return newTopic
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (topic UserConversationCallTopic) String() string {
+	if len(topic.Targets) == 0 {
+		return topic.GetType()
+	}
+	return topicNameWith(topic, topic.Targets...)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (topic *UserConversationCallTopic) UnmarshalJSON(payload []byte) (err error) {
+	event, err := unmarshalUserConversationEvent(topic.GetType(), payload, func(participant *Participant, call *ConversationCall) {
+		participant.Calls = []*ConversationCall{call}
+	})
+	if err != nil {
+		return err
+	}
+	topic.Name = event.Name
+	topic.User = event.User
+	topic.ConversationID = event.ConversationID
+	topic.Participants = event.Participants
+	topic.CorrelationID = event.CorrelationID
+	return
+}
@@ -0,0 +1,68 @@
+package gcloudcx
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserConversationCallbackTopic describes a Topic about User's Callbacks
+//
+// Each Participant carries its callback media in Participant.Callbacks.
+//
+// See: https://developer.genesys.cloud/notificationsalerts/notifications/available-topics#v2-users--id--conversations-callbacks
+type UserConversationCallbackTopic struct {
+	Name           string
+	User           *User
+	ConversationID uuid.UUID
+	Participants   []*Participant
+	CorrelationID  string
+	Targets        []Identifiable
+}
+
+func init() {
+	notificationTopicRegistry.Add(UserConversationCallbackTopic{})
+}
+
+// GetType returns the type of this topic
+//
+// implements core.TypeCarrier
+func (topic UserConversationCallbackTopic) GetType() string {
+	return "v2.users.{id}.conversations.callbacks"
+}
+
+// GetTargets returns the targets of this topic
+func (topic UserConversationCallbackTopic) GetTargets() []Identifiable {
+	return topic.Targets
+}
+
+// With creates a new NotificationTopic with the given targets
+func (topic UserConversationCallbackTopic) With(targets ...Identifiable) NotificationTopic {
+	newTopic := topic
+	newTopic.Targets = targets
+	return newTopic
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (topic UserConversationCallbackTopic) String() string {
+	if len(topic.Targets) == 0 {
+		return topic.GetType()
+	}
+	return topicNameWith(topic, topic.Targets...)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (topic *UserConversationCallbackTopic) UnmarshalJSON(payload []byte) (err error) {
+	event, err := unmarshalUserConversationEvent(topic.GetType(), payload, func(participant *Participant, callback *ConversationCallback) {
+		participant.Callbacks = []*ConversationCallback{callback}
+	})
+	if err != nil {
+		return err
+	}
+	topic.Name = event.Name
+	topic.User = event.User
+	topic.ConversationID = event.ConversationID
+	topic.Participants = event.Participants
+	topic.CorrelationID = event.CorrelationID
+	return
+}
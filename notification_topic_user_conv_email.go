@@ -0,0 +1,68 @@
+package gcloudcx
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserConversationEmailTopic describes a Topic about User's Emails
+//
+// Each Participant carries its email media in Participant.Emails.
+//
+// See: https://developer.genesys.cloud/notificationsalerts/notifications/available-topics#v2-users--id--conversations-emails
+type UserConversationEmailTopic struct {
+	Name           string
+	User           *User
+	ConversationID uuid.UUID
+	Participants   []*Participant
+	CorrelationID  string
+	Targets        []Identifiable
+}
+
+func init() {
+	notificationTopicRegistry.Add(UserConversationEmailTopic{})
+}
+
+// GetType returns the type of this topic
+//
+// implements core.TypeCarrier
+func (topic UserConversationEmailTopic) GetType() string {
+	return "v2.users.{id}.conversations.emails"
+}
+
+// GetTargets returns the targets of this topic
+func (topic UserConversationEmailTopic) GetTargets() []Identifiable {
+	return topic.Targets
+}
+
+// With creates a new NotificationTopic with the given targets
+func (topic UserConversationEmailTopic) With(targets ...Identifiable) NotificationTopic {
+	newTopic := topic
+	newTopic.Targets = targets
+	return newTopic
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (topic UserConversationEmailTopic) String() string {
+	if len(topic.Targets) == 0 {
+		return topic.GetType()
+	}
+	return topicNameWith(topic, topic.Targets...)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (topic *UserConversationEmailTopic) UnmarshalJSON(payload []byte) (err error) {
+	event, err := unmarshalUserConversationEvent(topic.GetType(), payload, func(participant *Participant, email *ConversationEmail) {
+		participant.Emails = []*ConversationEmail{email}
+	})
+	if err != nil {
+		return err
+	}
+	topic.Name = event.Name
+	topic.User = event.User
+	topic.ConversationID = event.ConversationID
+	topic.Participants = event.Participants
+	topic.CorrelationID = event.CorrelationID
+	return
+}
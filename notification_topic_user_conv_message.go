@@ -0,0 +1,68 @@
+package gcloudcx
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserConversationMessageTopic describes a Topic about User's Messages
+//
+// Each Participant carries its message media in Participant.Messages.
+//
+// See: https://developer.genesys.cloud/notificationsalerts/notifications/available-topics#v2-users--id--conversations-messages
+type UserConversationMessageTopic struct {
+	Name           string
+	User           *User
+	ConversationID uuid.UUID
+	Participants   []*Participant
+	CorrelationID  string
+	Targets        []Identifiable
+}
+
+func init() {
+	notificationTopicRegistry.Add(UserConversationMessageTopic{})
+}
+
+// GetType returns the type of this topic
+//
+// implements core.TypeCarrier
+func (topic UserConversationMessageTopic) GetType() string {
+	return "v2.users.{id}.conversations.messages"
+}
+
+// GetTargets returns the targets of this topic
+func (topic UserConversationMessageTopic) GetTargets() []Identifiable {
+	return topic.Targets
+}
+
+// With creates a new NotificationTopic with the given targets
+func (topic UserConversationMessageTopic) With(targets ...Identifiable) NotificationTopic {
+	newTopic := topic
+	newTopic.Targets = targets
+	return newTopic
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (topic UserConversationMessageTopic) String() string {
+	if len(topic.Targets) == 0 {
+		return topic.GetType()
+	}
+	return topicNameWith(topic, topic.Targets...)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (topic *UserConversationMessageTopic) UnmarshalJSON(payload []byte) (err error) {
+	event, err := unmarshalUserConversationEvent(topic.GetType(), payload, func(participant *Participant, message *ConversationMessage) {
+		participant.Messages = []*ConversationMessage{message}
+	})
+	if err != nil {
+		return err
+	}
+	topic.Name = event.Name
+	topic.User = event.User
+	topic.ConversationID = event.ConversationID
+	topic.Participants = event.Participants
+	topic.CorrelationID = event.CorrelationID
+	return
+}
@@ -0,0 +1,68 @@
+package gcloudcx
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserConversationVideoTopic describes a Topic about User's Videos
+//
+// Each Participant carries its video media in Participant.Videos.
+//
+// See: https://developer.genesys.cloud/notificationsalerts/notifications/available-topics#v2-users--id--conversations-videos
+type UserConversationVideoTopic struct {
+	Name           string
+	User           *User
+	ConversationID uuid.UUID
+	Participants   []*Participant
+	CorrelationID  string
+	Targets        []Identifiable
+}
+
+func init() {
+	notificationTopicRegistry.Add(UserConversationVideoTopic{})
+}
+
+// GetType returns the type of this topic
+//
+// implements core.TypeCarrier
+func (topic UserConversationVideoTopic) GetType() string {
+	return "v2.users.{id}.conversations.videos"
+}
+
+// GetTargets returns the targets of this topic
+func (topic UserConversationVideoTopic) GetTargets() []Identifiable {
+	return topic.Targets
+}
+
+// With creates a new NotificationTopic with the given targets
+func (topic UserConversationVideoTopic) With(targets ...Identifiable) NotificationTopic {
+	newTopic := topic
+	newTopic.Targets = targets
+	return newTopic
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (topic UserConversationVideoTopic) String() string {
+	if len(topic.Targets) == 0 {
+		return topic.GetType()
+	}
+	return topicNameWith(topic, topic.Targets...)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (topic *UserConversationVideoTopic) UnmarshalJSON(payload []byte) (err error) {
+	event, err := unmarshalUserConversationEvent(topic.GetType(), payload, func(participant *Participant, video *ConversationVideo) {
+		participant.Videos = []*ConversationVideo{video}
+	})
+	if err != nil {
+		return err
+	}
+	topic.Name = event.Name
+	topic.User = event.User
+	topic.ConversationID = event.ConversationID
+	topic.Participants = event.Participants
+	topic.CorrelationID = event.CorrelationID
+	return
+}
@@ -0,0 +1,79 @@
+package gcloudcx
+
+import (
+	"context"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// NotificationTopicValidation describes if a NotificationTopic can be subscribed to
+type NotificationTopicValidation struct {
+	Topic               NotificationTopic
+	Available           bool     // false if the topic is not in the available topics of the organization
+	Allowed             bool     // true if the topic is available and all its required permissions are granted
+	RequiredPermissions []string // the permissions required by the topic
+	MissingPermissions  []string // the required permissions that are not granted
+}
+
+// String gets a string version
+//
+//	implements the fmt.Stringer interface
+func (validation NotificationTopicValidation) String() string {
+	if validation.Allowed {
+		return validation.Topic.String() + ": allowed"
+	}
+	if !validation.Available {
+		return validation.Topic.String() + ": not available"
+	}
+	return validation.Topic.String() + ": denied"
+}
+
+// ValidateTopics checks if the client of this channel is allowed to subscribe to the given topics
+//
+// The required permissions of each topic are checked against the grants of the client's authorization subject.
+//
+// This is meant to be called before Subscribe, typically when the application starts.
+func (channel *NotificationChannel) ValidateTopics(context context.Context, topics ...NotificationTopic) (validations []NotificationTopicValidation, correlationID string, err error) {
+	if channel.Client == nil {
+		return []NotificationTopicValidation{}, "", errors.ArgumentMissing.With("client")
+	}
+	if channel.Client.Grant == nil || channel.Client.Grant.GetID() == uuid.Nil {
+		return []NotificationTopicValidation{}, "", errors.ArgumentMissing.With("id")
+	}
+	definitions, correlationID, err := channel.Client.GetAvailableNotificationTopics(context, "requiresPermissions")
+	if err != nil {
+		return []NotificationTopicValidation{}, correlationID, err
+	}
+	subject, correlationID, err := Fetch[AuthorizationSubject](context, channel.Client, channel.Client.Grant)
+	if err != nil {
+		return []NotificationTopicValidation{}, correlationID, err
+	}
+	return subject.CheckTopics(definitions, topics...), correlationID, nil
+}
+
+// CheckTopics checks if the subject is allowed to subscribe to the given topics
+//
+// The definitions are typically retrieved with Client.GetAvailableNotificationTopics(context, "requiresPermissions")
+func (subject AuthorizationSubject) CheckTopics(definitions []NotificationTopicDefinition, topics ...NotificationTopic) (validations []NotificationTopicValidation) {
+	validations = make([]NotificationTopicValidation, 0, len(topics))
+	for _, topic := range topics {
+		validation := NotificationTopicValidation{Topic: topic, RequiredPermissions: []string{}, MissingPermissions: []string{}}
+		for _, definition := range definitions {
+			if definition.ID == topic.GetType() {
+				validation.Available = true
+				validation.RequiredPermissions = append(validation.RequiredPermissions, definition.Permissions...)
+				break
+			}
+		}
+		if validation.Available {
+			if len(validation.RequiredPermissions) > 0 {
+				_, denied := subject.CheckScopes(validation.RequiredPermissions...)
+				validation.MissingPermissions = append(validation.MissingPermissions, denied...)
+			}
+			validation.Allowed = len(validation.MissingPermissions) == 0
+		}
+		validations = append(validations, validation)
+	}
+	return
+}
@@ -12,21 +12,21 @@ import (
 
 // Participant describes a Chat Participant
 type Participant struct {
-	ID              uuid.UUID `json:"id"`
-	SelfURI         URI       `json:"selfUri"`
-	Type            string    `json:"type"`
-	Provider        string    `json:"provider"`
-	Name            string    `json:"name"`
-	ParticipantType string    `json:"participantType,omitempty"`
-	State           string    `json:"state,omitempty"`
-	Held            bool      `json:"held,omitempty"`
-	Direction       string    `json:"direction,omitempty"`
-	StartTime       time.Time `json:"startTime,omitempty"`
-	ConnectedTime   time.Time `json:"connectedTime,omitempty"`
-	EndTime         time.Time `json:"endTime,omitempty"`
-	StartHoldTime   time.Time `json:"startHoldTime,omitempty"`
-	Purpose         string    `json:"purpose"`
-	DisconnectType  string    `json:"disconnectType,omitempty"`
+	ID              uuid.UUID          `json:"id"`
+	SelfURI         URI                `json:"selfUri"`
+	Type            string             `json:"type"`
+	Provider        string             `json:"provider"`
+	Name            string             `json:"name"`
+	ParticipantType string             `json:"participantType,omitempty"`
+	State           ConversationState  `json:"state,omitempty"`
+	Held            bool               `json:"held,omitempty"`
+	Direction       Direction          `json:"direction,omitempty"`
+	StartTime       time.Time          `json:"startTime,omitempty"`
+	ConnectedTime   time.Time          `json:"connectedTime,omitempty"`
+	EndTime         time.Time          `json:"endTime,omitempty"`
+	StartHoldTime   time.Time          `json:"startHoldTime,omitempty"`
+	Purpose         ParticipantPurpose `json:"purpose"`
+	DisconnectType  DisconnectType     `json:"disconnectType,omitempty"`
 
 	User                   *User            `json:"user,omitempty"`
 	ExternalContact        *DomainEntityRef `json:"externalContact,omitempty"`
@@ -177,7 +177,12 @@ func (participant *Participant) Disconnect(context context.Context, target Disco
 }
 
 // UpdateState updates the state of the Participant in target
-func (participant *Participant) UpdateState(context context.Context, target StateUpdater, state string) (correlationID string, err error) {
+//
+// The transition from the current state of the Participant is validated before sending the request
+func (participant *Participant) UpdateState(context context.Context, target StateUpdater, state ConversationState) (correlationID string, err error) {
+	if err = participant.State.ValidateTransition(state); err != nil {
+		return
+	}
 	return target.UpdateState(context, participant, state)
 }
 
@@ -226,6 +231,15 @@ func (participant *Participant) UnmarshalJSON(payload []byte) (err error) {
 	if participant.User == nil && len(inner.UserID) > 0 {
 		participant.User = &User{ID: inner.UserID}
 	}
+	if inner.QueueID != uuid.Nil {
+		participant.QueueID = inner.QueueID.String()
+		if participant.Queue == nil {
+			participant.Queue = &Queue{ID: inner.QueueID, Name: inner.QueueName}
+		}
+	}
+	if len(inner.QueueName) > 0 {
+		participant.QueueName = inner.QueueName
+	}
 	participant.AlertingTimeout = time.Duration(inner.AlertingTimeoutMs) * time.Millisecond
 	participant.WrapupTimeout = time.Duration(inner.WrapupTimeoutMs) * time.Millisecond
 	return
@@ -0,0 +1,285 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// UpdateAttributes updates the attributes of this Participant in the target
+//
+// The attributes are merged with the existing ones, on success they are also merged in this Participant.
+func (participant *Participant) UpdateAttributes(context context.Context, target AttributesUpdater, attributes map[string]string) (correlationID string, err error) {
+	if correlationID, err = target.UpdateAttributes(context, participant, attributes); err != nil {
+		return
+	}
+	if participant.Attributes == nil {
+		participant.Attributes = map[string]string{}
+	}
+	for key, value := range attributes {
+		participant.Attributes[key] = value
+	}
+	return
+}
+
+// DecodeAttributes decodes the attributes of this Participant into the given struct pointer
+//
+// See DecodeAttributesInto for the supported tags and types.
+func (participant Participant) DecodeAttributes(target any) error {
+	return DecodeAttributesInto(participant.Attributes, target)
+}
+
+// DecodeAttributes decodes participant attributes into a new T, which must be a struct
+//
+// See DecodeAttributesInto for the supported tags and types.
+func DecodeAttributes[T any](attributes map[string]string) (*T, error) {
+	var value T
+	if err := DecodeAttributesInto(attributes, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// DecodeAttributesInto decodes participant attributes into the given struct pointer
+//
+// The attribute of a field is given by the "attribute" tag, or the field name if there is no tag.
+// Fields tagged with "-" are ignored, as are missing or empty attributes.
+//
+// Since attributes are always strings, values are converted to the field's type:
+// strings, booleans, integers, floats, time.Duration (e.g. "1m30s"),
+// []string (comma-separated), and any type implementing encoding.TextUnmarshaler (e.g. time.Time, uuid.UUID).
+// Pointers to these types are allocated as needed.
+func DecodeAttributesInto(attributes map[string]string, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return errors.ArgumentInvalid.With("target", reflect.TypeOf(target), "pointer to struct")
+	}
+	var merr errors.MultiError
+	decodeAttributeFields(attributes, value.Elem(), &merr)
+	return merr.AsError()
+}
+
+// EncodeAttributes encodes the given struct (or pointer to struct) into participant attributes
+//
+// The tags and types are the same as DecodeAttributesInto, and "omitempty" skips zero values.
+// Nil pointers are always skipped.
+func EncodeAttributes[T any](source T) (map[string]string, error) {
+	value := reflect.ValueOf(source)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, errors.ArgumentMissing.With("source")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, errors.ArgumentInvalid.With("source", value.Type(), "struct")
+	}
+	attributes := map[string]string{}
+	var merr errors.MultiError
+	encodeAttributeFields(value, attributes, &merr)
+	if err := merr.AsError(); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// updateParticipantAttributes sends the attributes to the given participant URI
+func updateParticipantAttributes(context context.Context, client *Client, uri URI, attributes map[string]string) (correlationID string, err error) {
+	if client == nil {
+		return "", errors.ArgumentMissing.With("client")
+	}
+	if len(attributes) == 0 {
+		return "", errors.ArgumentMissing.With("attributes")
+	}
+	return client.Patch(
+		context,
+		uri,
+		struct {
+			Attributes map[string]string `json:"attributes"`
+		}{Attributes: attributes},
+		nil,
+	)
+}
+
+// attributeTag gets the attribute name and options of a struct field
+func attributeTag(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	tag, found := field.Tag.Lookup("attribute")
+	if !found {
+		return field.Name, false, false
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if len(name) == 0 {
+		name = field.Name
+	}
+	return name, options == "omitempty", false
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func decodeAttributeFields(attributes map[string]string, value reflect.Value, merr *errors.MultiError) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			decodeAttributeFields(attributes, value.Field(i), merr)
+			continue
+		}
+		name, _, skip := attributeTag(field)
+		if skip {
+			continue
+		}
+		if text, found := attributes[name]; found && len(text) > 0 {
+			if err := decodeAttribute(text, value.Field(i)); err != nil {
+				merr.Append(errors.ArgumentInvalid.With(name, text, field.Type.String()))
+			}
+		}
+	}
+}
+
+func decodeAttribute(text string, value reflect.Value) error {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return decodeAttribute(text, value.Elem())
+	}
+	if value.CanAddr() && value.Addr().Type().Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		switch strings.ToLower(text) {
+		case "yes", "y", "on":
+			value.SetBool(true)
+		case "no", "n", "off":
+			value.SetBool(false)
+		default:
+			parsed, err := strconv.ParseBool(text)
+			if err != nil {
+				return err
+			}
+			value.SetBool(parsed)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(text), 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(strings.TrimSpace(text), 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(text), value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return errors.InvalidType.With(value.Type().String())
+		}
+		items := strings.Split(text, ",")
+		slice := reflect.MakeSlice(value.Type(), 0, len(items))
+		for _, item := range items {
+			if item = strings.TrimSpace(item); len(item) > 0 {
+				slice = reflect.Append(slice, reflect.ValueOf(item).Convert(value.Type().Elem()))
+			}
+		}
+		value.Set(slice)
+	default:
+		return errors.InvalidType.With(value.Type().String())
+	}
+	return nil
+}
+
+func encodeAttributeFields(value reflect.Value, attributes map[string]string, merr *errors.MultiError) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			encodeAttributeFields(value.Field(i), attributes, merr)
+			continue
+		}
+		name, omitEmpty, skip := attributeTag(field)
+		if skip {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if omitEmpty && fieldValue.IsZero() {
+			continue
+		}
+		text, err := encodeAttribute(fieldValue)
+		if err != nil {
+			merr.Append(errors.ArgumentInvalid.With(name, fieldValue.Interface(), "attribute"))
+			continue
+		}
+		attributes[name] = text
+	}
+}
+
+func encodeAttribute(value reflect.Value) (string, error) {
+	if value.Type().Implements(textMarshalerType) {
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String(), nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.String {
+			items := make([]string, 0, value.Len())
+			for i := 0; i < value.Len(); i++ {
+				items = append(items, value.Index(i).String())
+			}
+			return strings.Join(items, ","), nil
+		}
+	}
+	return "", errors.InvalidType.With(value.Type().String())
+}
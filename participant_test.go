@@ -0,0 +1,173 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type ParticipantSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestParticipantSuite(t *testing.T) {
+	suite.Run(t, new(ParticipantSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *ParticipantSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *ParticipantSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *ParticipantSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *ParticipantSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *ParticipantSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+type customerAttributes struct {
+	AccountNumber string        `attribute:"accountNumber"`
+	Verified      bool          `attribute:"verified"`
+	Balance       float64       `attribute:"balance"`
+	Attempts      int           `attribute:"attempts,omitempty"`
+	Wait          time.Duration `attribute:"wait"`
+	CustomerID    uuid.UUID     `attribute:"customerId"`
+	Tags          []string      `attribute:"tags,omitempty"`
+	LastCall      *time.Time    `attribute:"lastCall"`
+	Internal      string        `attribute:"-"`
+	Segment       string
+}
+
+func (suite *ParticipantSuite) TestCanDecodeAttributes() {
+	customerID := uuid.New()
+	participant := gcloudcx.Participant{
+		ID: uuid.New(),
+		Attributes: map[string]string{
+			"accountNumber": "12345",
+			"verified":      "yes",
+			"balance":       "12.50",
+			"wait":          "1m30s",
+			"customerId":    customerID.String(),
+			"tags":          "gold, vip",
+			"lastCall":      "2024-01-01T10:00:00Z",
+			"Internal":      "secret",
+			"Segment":       "retail",
+		},
+	}
+	var attributes customerAttributes
+	err := participant.DecodeAttributes(&attributes)
+	suite.Require().NoErrorf(err, "Failed to decode attributes. %s", err)
+	suite.Assert().Equal("12345", attributes.AccountNumber)
+	suite.Assert().True(attributes.Verified)
+	suite.Assert().Equal(12.5, attributes.Balance)
+	suite.Assert().Equal(90*time.Second, attributes.Wait)
+	suite.Assert().Equal(customerID, attributes.CustomerID)
+	suite.Assert().Equal([]string{"gold", "vip"}, attributes.Tags)
+	suite.Require().NotNil(attributes.LastCall)
+	suite.Assert().Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), attributes.LastCall.UTC())
+	suite.Assert().Empty(attributes.Internal)
+	suite.Assert().Equal("retail", attributes.Segment)
+
+	_, err = gcloudcx.DecodeAttributes[customerAttributes](map[string]string{"verified": "maybe", "balance": "lots"})
+	suite.Require().Error(err, "Decoding invalid attributes should fail")
+	suite.Assert().Contains(err.Error(), "verified")
+	suite.Assert().Contains(err.Error(), "balance")
+}
+
+func (suite *ParticipantSuite) TestCanEncodeAttributes() {
+	customerID := uuid.New()
+	attributes, err := gcloudcx.EncodeAttributes(customerAttributes{
+		AccountNumber: "12345",
+		Verified:      true,
+		Balance:       12.5,
+		Wait:          90 * time.Second,
+		CustomerID:    customerID,
+		Internal:      "secret",
+	})
+	suite.Require().NoErrorf(err, "Failed to encode attributes. %s", err)
+	suite.Assert().Equal(map[string]string{
+		"accountNumber": "12345",
+		"verified":      "true",
+		"balance":       "12.5",
+		"wait":          "1m30s",
+		"customerId":    customerID.String(),
+		"Segment":       "",
+	}, attributes)
+}
+
+func (suite *ParticipantSuite) TestCanUpdateAttributes() {
+	conversationID := uuid.New()
+	participant := &gcloudcx.Participant{ID: uuid.New(), Attributes: map[string]string{"accountNumber": "12345"}}
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PATCH /api/v2/conversations/%s/participants/%s/attributes", conversationID, participant.ID):       struct{}{},
+		fmt.Sprintf("PATCH /api/v2/conversations/calls/%s/participants/%s/attributes", conversationID, participant.ID): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	conversation := gcloudcx.New[gcloudcx.Conversation](context.Background(), client, conversationID, suite.Logger)
+
+	_, err := participant.UpdateAttributes(context.Background(), conversation, map[string]string{"verified": "true"})
+	suite.Require().NoErrorf(err, "Failed to update attributes. %s", err)
+	suite.Assert().JSONEq(`{"attributes": {"verified": "true"}}`, string(server.LastRequest().Body))
+	suite.Assert().Equal(map[string]string{"accountNumber": "12345", "verified": "true"}, participant.Attributes)
+
+	call := gcloudcx.New[gcloudcx.ConversationCall](context.Background(), client, conversationID, suite.Logger)
+	_, err = call.UpdateAttributes(context.Background(), participant, map[string]string{"verified": "false"})
+	suite.Require().NoErrorf(err, "Failed to update call attributes. %s", err)
+	suite.Assert().Equal(http.MethodPatch, server.LastRequest().Method)
+
+	_, err = conversation.UpdateAttributes(context.Background(), participant, nil)
+	suite.Assert().Error(err, "Updating no attributes should fail")
+}
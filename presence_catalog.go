@@ -0,0 +1,117 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// PresenceCatalog caches the presence definitions of the organization
+//
+// It resolves presence names like "Available" or "Break" to their PresenceDefinition.
+// The definitions are fetched again once the TTL has elapsed.
+//
+// Each Client has its own catalog, see Client.PresenceCatalog.
+type PresenceCatalog struct {
+	TTL         time.Duration
+	client      *Client
+	definitions []PresenceDefinition
+	fetched     time.Time
+	mutex       sync.Mutex
+}
+
+// DefaultPresenceCatalogTTL is how long presence definitions are cached by default
+const DefaultPresenceCatalogTTL = 1 * time.Hour
+
+// NewPresenceCatalog creates a new PresenceCatalog
+func NewPresenceCatalog(client *Client, ttl time.Duration) *PresenceCatalog {
+	return &PresenceCatalog{client: client, TTL: ttl}
+}
+
+// PresenceCatalog gets the PresenceCatalog of this Client
+func (client *Client) PresenceCatalog() *PresenceCatalog {
+	if client.presences == nil {
+		client.presences = NewPresenceCatalog(client, DefaultPresenceCatalogTTL)
+	}
+	return client.presences
+}
+
+// Definitions gets the active presence definitions of the organization
+//
+// The definitions are fetched if they are not cached or if the cache expired.
+func (catalog *PresenceCatalog) Definitions(context context.Context) ([]PresenceDefinition, string, error) {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	if catalog.definitions != nil && time.Since(catalog.fetched) < catalog.TTL {
+		return catalog.definitions, "", nil
+	}
+	if catalog.client == nil {
+		return nil, "", errors.ArgumentMissing.With("client")
+	}
+	entities, correlationID, err := catalog.client.FetchEntities(context, NewURI("/presencedefinitions"))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	definitions := make([]PresenceDefinition, 0, len(entities))
+	for _, entity := range entities {
+		var definition PresenceDefinition
+		if err := json.Unmarshal(entity, &definition); err != nil {
+			return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+		}
+		if !definition.Deactivated {
+			definitions = append(definitions, definition)
+		}
+	}
+	catalog.definitions = definitions
+	catalog.fetched = time.Now()
+	return definitions, correlationID, nil
+}
+
+// Resolve gets the PresenceDefinition with the given name
+//
+// The name is compared, case insensitively, to the labels of the definitions first, then to their system presence.
+// When several definitions share a system presence, the primary one is preferred.
+func (catalog *PresenceCatalog) Resolve(context context.Context, name string) (*PresenceDefinition, string, error) {
+	if len(name) == 0 {
+		return nil, "", errors.ArgumentMissing.With("name")
+	}
+	definitions, correlationID, err := catalog.Definitions(context)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	for _, definition := range definitions {
+		for _, label := range definition.LanguageLabels {
+			if strings.EqualFold(label, name) {
+				return &definition, correlationID, nil
+			}
+		}
+	}
+	var found *PresenceDefinition
+	for index, definition := range definitions {
+		if normalizePresenceName(definition.SystemPresence) == normalizePresenceName(name) {
+			if found == nil || (definition.Primary && !found.Primary) {
+				found = &definitions[index]
+			}
+		}
+	}
+	if found == nil {
+		return nil, correlationID, errors.NotFound.With("presence", name)
+	}
+	return found, correlationID, nil
+}
+
+// Invalidate clears the cache of this PresenceCatalog
+func (catalog *PresenceCatalog) Invalidate() {
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	catalog.definitions = nil
+}
+
+// normalizePresenceName normalizes system presences like "On Queue" and "ON_QUEUE"
+func normalizePresenceName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+}
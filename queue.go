@@ -11,23 +11,38 @@ import (
 
 // Queue defines a GCloud Queue
 type Queue struct {
-	ID                    uuid.UUID      `json:"id"`
-	Name                  string         `json:"name"`
-	CreatedBy             *User          `json:"-"`
-	ModifiedBy            string         `json:"modifiedBy"`
-	DateCreated           time.Time      `json:"dateCreated"`
-	Division              *Division      `json:"division"`
-	MemberCount           int            `json:"memberCount"`
-	MediaSettings         MediaSettings  `json:"mediaSettings"`
-	ACWSettings           ACWSettings    `json:"acwSettings"`
-	SkillEvaluationMethod string         `json:"skillEvaluationMethod"`
-	AutoAnswerOnly        bool           `json:"true"`
-	DefaultScripts        interface{}    `json:"defaultScripts"`
-	SelfURI               URI            `json:"selfUri"`
-	client                *Client        `json:"-"`
-	logger                *logger.Logger `json:"-"`
+	ID                    uuid.UUID          `json:"id"`
+	Name                  string             `json:"name"`
+	Description           string             `json:"description,omitempty"`
+	CreatedBy             *User              `json:"-"`
+	ModifiedBy            string             `json:"modifiedBy"`
+	DateCreated           time.Time          `json:"dateCreated"`
+	Division              *Division          `json:"division"`
+	MemberCount           int                `json:"memberCount"`
+	MediaSettings         MediaSettings      `json:"mediaSettings"`
+	ACWSettings           ACWSettings        `json:"acwSettings"`
+	SkillEvaluationMethod string             `json:"skillEvaluationMethod"`
+	Bullseye              *QueueBullseye     `json:"bullseye,omitempty"`
+	AutoAnswerOnly        bool               `json:"autoAnswerOnly"`
+	OutboundEmailAddress  *QueueEmailAddress `json:"outboundEmailAddress,omitempty"`
+	DefaultScripts        QueueScripts       `json:"defaultScripts,omitempty"`
+	Version               int                `json:"version,omitempty"`
+	SelfURI               URI                `json:"selfUri"`
+	client                *Client            `json:"-"`
+	logger                *logger.Logger     `json:"-"`
+	raw                   json.RawMessage    `json:"-"` // the payload this Queue was unmarshaled from, used by Request
 }
 
+// QueueScripts is a map of media types and the default Script of a Queue for these media
+type QueueScripts map[string]DomainEntityRef
+
+// Skill evaluation methods of a Queue
+const (
+	SkillEvaluationNone = "NONE" // agents are selected regardless of their skills
+	SkillEvaluationBest = "BEST" // the agents with the best skill proficiency are selected
+	SkillEvaluationAll  = "ALL"  // all agents with the skills are selected
+)
+
 // RoutingTarget describes a routing target
 type RoutingTarget struct {
 	Type    string `json:"targetType,omitempty"`
@@ -42,12 +57,17 @@ type RoutingTarget struct {
 func (queue *Queue) Initialize(parameters ...interface{}) {
 	for _, raw := range parameters {
 		switch parameter := raw.(type) {
+		case uuid.UUID:
+			queue.ID = parameter
 		case *Client:
 			queue.client = parameter
 		case *logger.Logger:
 			queue.logger = parameter.Child("queue", "queue", "id", queue.ID)
 		}
 	}
+	if queue.logger == nil {
+		queue.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
 }
 
 // GetID gets the identifier of this
@@ -70,6 +90,16 @@ func (queue Queue) GetURI(ids ...uuid.UUID) URI {
 	return URI("/api/v2/routing/queues/")
 }
 
+// GetName gets the name of this
+//
+// implements Named
+func (queue Queue) GetName() string {
+	return queue.Name
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
 func (queue Queue) String() string {
 	if len(queue.Name) > 0 {
 		return queue.Name
@@ -89,6 +119,7 @@ func (queue *Queue) UnmarshalJSON(payload []byte) (err error) {
 		return errors.JSONUnmarshalError.Wrap(err)
 	}
 	*queue = Queue(inner.surrogate)
+	queue.raw = append(json.RawMessage(nil), payload...)
 	if len(inner.CreatedByID) > 0 {
 		queue.CreatedBy = &User{ID: inner.CreatedByID}
 	}
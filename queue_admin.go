@@ -0,0 +1,69 @@
+package gcloudcx
+
+import (
+	"context"
+
+	"github.com/gildas/go-errors"
+)
+
+// CreateQueue creates a Queue
+func (client *Client) CreateQueue(context context.Context, request *QueueRequest) (*Queue, string, error) {
+	if request == nil {
+		return nil, "", errors.ArgumentMissing.With("request")
+	}
+	if err := request.Validate(); err != nil {
+		return nil, "", err
+	}
+	queue := &Queue{}
+	correlationID, err := client.Post(context, NewURI("/routing/queues"), request, queue)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	queue.Initialize(client, client.Logger)
+	return queue, correlationID, nil
+}
+
+// Update updates this Queue
+//
+// Genesys Cloud replaces the whole Queue: the settings that are not in the request are reset.
+// Use Queue.Request to build the request from this Queue, it keeps all the settings of the Queue,
+// including the ones this library does not model.
+//
+// The updated Queue is returned.
+func (queue Queue) Update(context context.Context, request *QueueRequest) (*Queue, string, error) {
+	if err := queue.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if request == nil {
+		return nil, "", errors.ArgumentMissing.With("request")
+	}
+	if err := request.Validate(); err != nil {
+		return nil, "", err
+	}
+	updated := &Queue{}
+	correlationID, err := queue.client.Put(queue.logger.ToContext(context), NewURI("/routing/queues/%s", queue.ID), request, updated)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	updated.Initialize(queue.client, queue.client.Logger)
+	return updated, correlationID, nil
+}
+
+// Delete deletes this Queue
+//
+// If force is true, the Queue is deleted even if it has members or is referenced by flows
+func (queue Queue) Delete(context context.Context, force bool) (correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	uri := NewURI("/routing/queues/%s", queue.ID)
+	if force {
+		uri = uri.WithQuery(Query{"forceDelete": true})
+	}
+	return queue.client.Delete(queue.logger.ToContext(context), uri, nil)
+}
+
+// checkInitialized checks if this Queue can send requests
+func (queue Queue) checkInitialized() error {
+	return checkInitialized(queue.client, "Queue", queue.ID)
+}
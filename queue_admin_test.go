@@ -0,0 +1,297 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type QueueAdminSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestQueueAdminSuite(t *testing.T) {
+	suite.Run(t, new(QueueAdminSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *QueueAdminSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *QueueAdminSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *QueueAdminSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *QueueAdminSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *QueueAdminSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *QueueAdminSuite) TestCanUnmarshalAutoAnswerOnly() {
+	queue := gcloudcx.Queue{}
+	err := json.Unmarshal([]byte(`{"id": "06ffcd2e-1ada-412e-a5f5-30d7853246dd", "name": "Support", "autoAnswerOnly": true, "bullseye": {"rings": [{"expansionTimeoutSeconds": 15.5}]}, "defaultScripts": {"CALL": {"id": "f5a9e8b1-2b9b-4c4f-9c55-5a3e1a8f1d10"}}}`), &queue)
+	suite.Require().NoErrorf(err, "Failed to unmarshal queue. %s", err)
+	suite.Assert().True(queue.AutoAnswerOnly)
+	suite.Require().NotNil(queue.Bullseye)
+	suite.Require().Len(queue.Bullseye.Rings, 1)
+	suite.Assert().Equal(15500*time.Millisecond, queue.Bullseye.Rings[0].ExpansionTimeout)
+	suite.Assert().Equal(uuid.MustParse("f5a9e8b1-2b9b-4c4f-9c55-5a3e1a8f1d10"), queue.DefaultScripts["CALL"].ID)
+}
+
+func (suite *QueueAdminSuite) TestCanMarshalQueueRequest() {
+	skillID := uuid.MustParse("3b6e5c9a-8f0d-4b4c-9a57-2f0b1c6d7e8f")
+	scriptID := uuid.MustParse("f5a9e8b1-2b9b-4c4f-9c55-5a3e1a8f1d10")
+	request := gcloudcx.NewQueueRequest("Support").
+		WithDescription("Support queue").
+		WithMediaSetting("call", gcloudcx.MediaSetting{AlertingTimeout: 8 * time.Second, ServiceLevel: gcloudcx.ServiceLevel{Percentage: 0.8, Duration: 20 * time.Second}}).
+		WithACWSettings(gcloudcx.ACWSettings{WrapupPrompt: gcloudcx.WrapupPromptOptional, Timeout: 30 * time.Second}).
+		WithSkillEvaluationMethod(gcloudcx.SkillEvaluationBest).
+		WithBullseyeRings(gcloudcx.NewQueueBullseyeRing(10*time.Second, gcloudcx.EntityRef{ID: skillID}), gcloudcx.NewQueueBullseyeRing(0)).
+		WithDefaultScript("CALL", gcloudcx.EntityRef{ID: scriptID})
+	suite.Require().NoError(request.Validate())
+
+	data, err := json.Marshal(request)
+	suite.Require().NoErrorf(err, "Failed to marshal queue request. %s", err)
+	expected := fmt.Sprintf(`{
+		"name": "Support",
+		"description": "Support queue",
+		"mediaSettings": {"call": {"alertingTimeoutSeconds": 8, "serviceLevel": {"percentage": 0.8, "durationMs": 20000}}},
+		"acwSettings": {"wrapupPrompt": "OPTIONAL", "timeoutMs": 30000},
+		"skillEvaluationMethod": "BEST",
+		"bullseye": {"rings": [{"expansionTimeoutSeconds": 10, "skillsToRemove": [{"id": "%s"}]}, {"expansionTimeoutSeconds": 0}]},
+		"defaultScripts": {"CALL": {"id": "%s"}},
+		"autoAnswerOnly": false
+	}`, skillID, scriptID)
+	suite.Assert().JSONEq(expected, string(data))
+}
+
+func (suite *QueueAdminSuite) TestShouldNotValidateInvalidQueueRequest() {
+	rings := make([]gcloudcx.QueueBullseyeRing, 7)
+	err := gcloudcx.NewQueueRequest("").WithSkillEvaluationMethod("SOME").WithBullseyeRings(rings...).Validate()
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+}
+
+func (suite *QueueAdminSuite) TestCanCreateUpdateAndDeleteQueue() {
+	queueID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/routing/queues":                            map[string]any{"id": queueID, "name": "Support", "skillEvaluationMethod": "ALL", "acwSettings": map[string]any{"wrapupPrompt": "MANDATORY"}},
+		fmt.Sprintf("PUT /api/v2/routing/queues/%s", queueID):    map[string]any{"id": queueID, "name": "Support", "skillEvaluationMethod": "BEST", "acwSettings": map[string]any{"wrapupPrompt": "MANDATORY"}},
+		fmt.Sprintf("DELETE /api/v2/routing/queues/%s", queueID): nil,
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	queue, _, err := client.CreateQueue(context.Background(), gcloudcx.NewQueueRequest("Support").WithSkillEvaluationMethod(gcloudcx.SkillEvaluationAll))
+	suite.Require().NoErrorf(err, "Failed to create queue. %s", err)
+	suite.Assert().Equal(queueID, queue.ID)
+
+	queue, _, err = queue.Update(context.Background(), queue.Request().WithSkillEvaluationMethod(gcloudcx.SkillEvaluationBest))
+	suite.Require().NoErrorf(err, "Failed to update queue. %s", err)
+	suite.Assert().Equal(gcloudcx.SkillEvaluationBest, queue.SkillEvaluationMethod)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"id": "%s", "name": "Support", "skillEvaluationMethod": "BEST", "acwSettings": {"wrapupPrompt": "MANDATORY", "timeoutMs": 0}, "autoAnswerOnly": false}`, queueID), string(server.LastRequest().Body))
+
+	_, err = queue.Delete(context.Background(), true)
+	suite.Require().NoErrorf(err, "Failed to delete queue. %s", err)
+	suite.Assert().Equal("true", server.LastRequest().Query.Get("forceDelete"))
+}
+
+func (suite *QueueAdminSuite) TestCanRoundTripQueueRequest() {
+	payload := `{
+		"id": "06ffcd2e-1ada-412e-a5f5-30d7853246dd",
+		"name": "Support",
+		"description": "Level 1",
+		"memberCount": 12,
+		"mediaSettings": {"email": {"alertingTimeoutSeconds": 300, "serviceLevel": {"percentage": 0.8, "durationMs": 86400000}}},
+		"acwSettings": {"timeoutMs": 0},
+		"skillEvaluationMethod": "ALL",
+		"autoAnswerOnly": true,
+		"outboundEmailAddress": {"domain": {"id": "acme.mypurecloud.com"}, "route": {"id": "3b6e5c9a-8f0d-4b4c-9a57-2f0b1c6d7e8f"}},
+		"enableTranscription": true,
+		"routingRules": [{"operator": "MEETS_THRESHOLD", "threshold": 9, "waitSeconds": 300}]
+	}`
+	queue := gcloudcx.Queue{}
+	err := json.Unmarshal([]byte(payload), &queue)
+	suite.Require().NoErrorf(err, "Failed to unmarshal queue. %s", err)
+
+	request := queue.Request()
+	suite.Require().NotNil(request.OutboundEmailAddress)
+	suite.Require().NotNil(request.ACWSettings, "ACW settings should be kept even without a wrap-up prompt")
+
+	data, err := json.Marshal(request)
+	suite.Require().NoErrorf(err, "Failed to marshal queue request. %s", err)
+	suite.Assert().JSONEq(payload, string(data), "An unmodified request should send the queue back unchanged")
+
+	data, err = json.Marshal(queue.Request().WithDescription(""))
+	suite.Require().NoErrorf(err, "Failed to marshal queue request. %s", err)
+	var merged map[string]any
+	suite.Require().NoError(json.Unmarshal(data, &merged))
+	suite.Assert().NotContains(merged, "description", "Cleared fields should be removed")
+	suite.Assert().Equal(true, merged["enableTranscription"], "Unmodeled fields should be kept")
+	suite.Assert().Contains(merged, "routingRules", "Unmodeled fields should be kept")
+	suite.Assert().Contains(merged, "outboundEmailAddress")
+}
+
+func (suite *QueueAdminSuite) TestShouldKeepUnmodeledSettingsWhenRoundTrippingQueueRequest() {
+	payload := `{
+		"id": "06ffcd2e-1ada-412e-a5f5-30d7853246dd",
+		"name": "Support",
+		"mediaSettings": {
+			"call": {"alertingTimeoutSeconds": 8, "serviceLevel": {"percentage": 0.8, "durationMs": 20000}, "enableAutoAnswer": true, "autoAnswerAlertToneSeconds": 2.5},
+			"callback": {"alertingTimeoutSeconds": 30, "serviceLevel": {"percentage": 0.8, "durationMs": 20000}, "mode": "AgentFirst", "enableAutoDialAndEnd": true}
+		},
+		"bullseye": {"rings": [
+			{"expansionTimeoutSeconds": 15, "memberGroups": [{"id": "3b6e5c9a-8f0d-4b4c-9a57-2f0b1c6d7e8f", "type": "GROUP"}]},
+			{"expansionTimeoutSeconds": 0}
+		]},
+		"acwSettings": {"timeoutMs": 0},
+		"skillEvaluationMethod": "ALL",
+		"autoAnswerOnly": false
+	}`
+	queue := gcloudcx.Queue{}
+	err := json.Unmarshal([]byte(payload), &queue)
+	suite.Require().NoErrorf(err, "Failed to unmarshal queue. %s", err)
+
+	request := queue.Request()
+	data, err := json.Marshal(request)
+	suite.Require().NoErrorf(err, "Failed to marshal queue request. %s", err)
+	suite.Assert().JSONEq(payload, string(data), "An unmodified request should send the queue back unchanged")
+
+	request.WithMediaSetting("call", gcloudcx.MediaSetting{AlertingTimeout: 12 * time.Second})
+	request.Bullseye.Rings[0].ExpansionTimeout = 30 * time.Second
+	data, err = json.Marshal(request)
+	suite.Require().NoErrorf(err, "Failed to marshal queue request. %s", err)
+	var merged struct {
+		MediaSettings map[string]map[string]any `json:"mediaSettings"`
+		Bullseye      struct {
+			Rings []map[string]any `json:"rings"`
+		} `json:"bullseye"`
+	}
+	suite.Require().NoError(json.Unmarshal(data, &merged))
+	suite.Assert().Equal(float64(12), merged.MediaSettings["call"]["alertingTimeoutSeconds"])
+	suite.Assert().Equal(true, merged.MediaSettings["call"]["enableAutoAnswer"], "Unmodeled media settings should be kept")
+	suite.Assert().Equal(2.5, merged.MediaSettings["call"]["autoAnswerAlertToneSeconds"], "Unmodeled media settings should be kept")
+	suite.Assert().Equal("AgentFirst", merged.MediaSettings["callback"]["mode"], "Unmodeled media settings should be kept")
+	suite.Require().Len(merged.Bullseye.Rings, 2)
+	suite.Assert().Equal(float64(30), merged.Bullseye.Rings[0]["expansionTimeoutSeconds"])
+	suite.Assert().Contains(merged.Bullseye.Rings[0], "memberGroups", "Unmodeled ring settings should be kept")
+}
+
+func (suite *QueueAdminSuite) TestCanFetchMembersWithFilter() {
+	queueID := uuid.New()
+	userID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/routing/queues/%s/members", queueID): map[string]any{
+			"entities":  []map[string]any{{"id": userID, "name": "John Doe", "joined": true, "memberBy": "user", "routingStatus": map[string]any{"status": "IDLE"}}},
+			"pageCount": 1,
+		},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	queue := gcloudcx.New[gcloudcx.Queue](context.Background(), client, queueID, suite.Logger)
+
+	joined := true
+	members, _, err := queue.FetchMembers(context.Background(), gcloudcx.QueueMemberFilter{
+		Joined:          &joined,
+		Presences:       []string{"Available", "Busy"},
+		RoutingStatuses: []string{gcloudcx.RoutingStatusIdle},
+	})
+	suite.Require().NoErrorf(err, "Failed to fetch members. %s", err)
+	suite.Require().Len(members, 1)
+	suite.Assert().Equal(userID, members[0].ID)
+	suite.Assert().True(members[0].Joined)
+	suite.Assert().Equal(gcloudcx.RoutingStatusIdle, members[0].RoutingStatus.Status)
+	query := server.LastRequest().Query
+	suite.Assert().Equal("true", query.Get("joined"))
+	suite.Assert().Equal([]string{"Available", "Busy"}, query["presence"])
+	suite.Assert().Equal([]string{"IDLE"}, query["routingStatus"])
+}
+
+func (suite *QueueAdminSuite) TestCanManageMembersInBulk() {
+	queueID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("POST /api/v2/routing/queues/%s/members", queueID):  nil,
+		fmt.Sprintf("PATCH /api/v2/routing/queues/%s/members", queueID): nil,
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	queue := gcloudcx.New[gcloudcx.Queue](context.Background(), client, queueID, suite.Logger)
+
+	users := make([]gcloudcx.Identifiable, 0, 150)
+	for range 150 {
+		users = append(users, gcloudcx.EntityRef{ID: uuid.New()})
+	}
+	_, err := queue.AddMembers(context.Background(), users...)
+	suite.Require().NoErrorf(err, "Failed to add members. %s", err)
+	suite.Require().Len(server.Requests, 2, "Members should be added in chunks of 100")
+	suite.Assert().Equal("false", server.Requests[0].Query.Get("delete"))
+	var payload []map[string]any
+	suite.Require().NoError(json.Unmarshal(server.LastRequest().Body, &payload))
+	suite.Assert().Len(payload, 50)
+
+	_, err = queue.RemoveMembers(context.Background(), users[0])
+	suite.Require().NoErrorf(err, "Failed to remove members. %s", err)
+	suite.Assert().Equal("true", server.LastRequest().Query.Get("delete"))
+
+	_, err = queue.SetMembersJoined(context.Background(), false, users[1], users[2])
+	suite.Require().NoErrorf(err, "Failed to unjoin members. %s", err)
+	suite.Assert().Equal(http.MethodPatch, server.LastRequest().Method)
+	suite.Assert().JSONEq(fmt.Sprintf(`[{"id": "%s", "joined": false}, {"id": "%s", "joined": false}]`, users[1].GetID(), users[2].GetID()), string(server.LastRequest().Body))
+
+	_, err = queue.AddMembers(context.Background())
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+}
@@ -0,0 +1,77 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// QueueBullseye defines the bullseye routing of a Queue
+//
+// Interactions start in the first ring, and expand to the next ring after its expansion timeout.
+// When a ring expands, its skills to remove are not required anymore.
+type QueueBullseye struct {
+	Rings []QueueBullseyeRing `json:"rings"`
+}
+
+// QueueBullseyeRing defines a ring of the bullseye routing of a Queue
+type QueueBullseyeRing struct {
+	ExpansionTimeout time.Duration
+	SkillsToRemove   []EntityRef
+}
+
+// MaxBullseyeRings is the maximum number of rings of a bullseye
+const MaxBullseyeRings = 6
+
+// NewQueueBullseyeRing creates a new QueueBullseyeRing
+func NewQueueBullseyeRing(expansionTimeout time.Duration, skillsToRemove ...Identifiable) QueueBullseyeRing {
+	ring := QueueBullseyeRing{ExpansionTimeout: expansionTimeout}
+	for _, skill := range skillsToRemove {
+		ring.SkillsToRemove = append(ring.SkillsToRemove, EntityRef{ID: skill.GetID()})
+	}
+	return ring
+}
+
+// Validate validates the bullseye
+func (bullseye QueueBullseye) Validate() error {
+	if len(bullseye.Rings) == 0 {
+		return errors.ArgumentMissing.With("rings")
+	}
+	if len(bullseye.Rings) > MaxBullseyeRings {
+		return errors.ArgumentInvalid.With("rings", len(bullseye.Rings), "at most 6 rings")
+	}
+	for _, ring := range bullseye.Rings {
+		if ring.ExpansionTimeout < 0 {
+			return errors.ArgumentInvalid.With("expansionTimeout", ring.ExpansionTimeout, "a positive duration")
+		}
+	}
+	return nil
+}
+
+// MarshalJSON marshals this into JSON
+func (ring QueueBullseyeRing) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		ExpansionTimeout float64     `json:"expansionTimeoutSeconds"`
+		SkillsToRemove   []EntityRef `json:"skillsToRemove,omitempty"`
+	}{
+		ExpansionTimeout: ring.ExpansionTimeout.Seconds(),
+		SkillsToRemove:   ring.SkillsToRemove,
+	})
+	return data, errors.JSONMarshalError.Wrap(err)
+}
+
+// UnmarshalJSON unmarshals JSON into this
+func (ring *QueueBullseyeRing) UnmarshalJSON(payload []byte) (err error) {
+	var inner struct {
+		ExpansionTimeout float64     `json:"expansionTimeoutSeconds"`
+		SkillsToRemove   []EntityRef `json:"skillsToRemove"`
+	}
+
+	if err = json.Unmarshal(payload, &inner); err != nil {
+		return errors.JSONUnmarshalError.Wrap(err)
+	}
+	ring.ExpansionTimeout = time.Duration(inner.ExpansionTimeout * float64(time.Second))
+	ring.SkillsToRemove = inner.SkillsToRemove
+	return
+}
@@ -1,6 +1,55 @@
 package gcloudcx
 
+import (
+	"context"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
 type QueueEmailAddress struct {
-	Domain DomainEntityRef    `json:"domain"`
+	Domain EmailDomainRef     `json:"domain"`
 	Route  *EmailInboundRoute `json:"route,omitempty"`
 }
+
+// EmailDomainRef describes a reference to an email domain
+//
+// Email domains are identified by their name (e.g. acme.mypurecloud.com), not by a UUID
+type EmailDomainRef struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	SelfURI URI    `json:"selfUri,omitempty"`
+}
+
+// GetRouteURI gets the URI of the given inbound route of this domain
+func (domain EmailDomainRef) GetRouteURI(routeID uuid.UUID) URI {
+	return NewURI("/api/v2/routing/email/domains/%s/routes/%s", domain.ID, routeID)
+}
+
+// FetchRoute fetches the inbound route of this address
+func (address QueueEmailAddress) FetchRoute(context context.Context, client *Client) (route *EmailInboundRoute, correlationID string, err error) {
+	if address.Route == nil || address.Route.ID == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("route")
+	}
+	if len(address.Domain.ID) == 0 {
+		return nil, "", errors.ArgumentMissing.With("domain")
+	}
+	route = &EmailInboundRoute{}
+	if correlationID, err = client.Get(context, address.Domain.GetRouteURI(address.Route.ID), route); err != nil {
+		return nil, correlationID, err
+	}
+	return route, correlationID, nil
+}
+
+// GetEmailAddress gets the email address of the given route in this address' domain
+//
+// The route's FromEmail is used if set, otherwise the address is built from the route's pattern.
+func (address QueueEmailAddress) GetEmailAddress(route *EmailInboundRoute) EmailAddress {
+	if route == nil {
+		return EmailAddress{}
+	}
+	if len(route.FromEmail) > 0 {
+		return EmailAddress{Name: route.FromName, Email: route.FromEmail}
+	}
+	return EmailAddress{Name: route.FromName, Email: route.Pattern + "@" + address.Domain.ID}
+}
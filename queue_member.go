@@ -0,0 +1,156 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// QueueMember describes a member of a Queue
+type QueueMember struct {
+	ID            uuid.UUID      `json:"id"`
+	Name          string         `json:"name"`
+	User          *User          `json:"user,omitempty"`
+	RingNumber    int            `json:"ringNumber,omitempty"`
+	Joined        bool           `json:"joined"`
+	MemberBy      string         `json:"memberBy,omitempty"` // user, group
+	RoutingStatus *RoutingStatus `json:"routingStatus,omitempty"`
+	SelfURI       URI            `json:"selfUri,omitempty"`
+}
+
+// QueueMemberFilter filters the members of a Queue
+//
+// Empty fields do not filter.
+type QueueMemberFilter struct {
+	Name            string
+	Joined          *bool
+	Presences       []string // system presences: Available, Away, Busy, ...
+	RoutingStatuses []string // OFF_QUEUE, IDLE, INTERACTING, NOT_RESPONDING, COMMUNICATING
+	MemberBy        string   // user, group
+}
+
+// queueMembersBulkSize is the maximum number of members Genesys Cloud accepts per bulk request
+const queueMembersBulkSize = 100
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (member QueueMember) GetID() uuid.UUID {
+	return member.ID
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (member QueueMember) String() string {
+	if len(member.Name) > 0 {
+		return member.Name
+	}
+	return member.ID.String()
+}
+
+// Query gets the query of this filter
+func (filter QueueMemberFilter) Query() Query {
+	query := Query{}
+	if len(filter.Name) > 0 {
+		query["name"] = filter.Name
+	}
+	if filter.Joined != nil {
+		query["joined"] = *filter.Joined
+	}
+	if len(filter.Presences) > 0 {
+		query["presence"] = filter.Presences
+	}
+	if len(filter.RoutingStatuses) > 0 {
+		query["routingStatus"] = filter.RoutingStatuses
+	}
+	if len(filter.MemberBy) > 0 {
+		query["memberBy"] = filter.MemberBy
+	}
+	return query
+}
+
+// FetchMembers fetches the members of this Queue that match the given filter
+func (queue Queue) FetchMembers(context context.Context, filter QueueMemberFilter) (members []*QueueMember, correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	entities, correlationID, err := queue.client.FetchEntities(queue.logger.ToContext(context), NewURI("/routing/queues/%s/members", queue.ID).WithQuery(filter.Query()))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	members = make([]*QueueMember, 0, len(entities))
+	for _, entity := range entities {
+		member := QueueMember{}
+		if err = json.Unmarshal(entity, &member); err != nil {
+			return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+		}
+		members = append(members, &member)
+	}
+	return members, correlationID, nil
+}
+
+// AddMembers adds users as members of this Queue
+//
+// The users are sent in bulk requests of at most 100 users.
+func (queue Queue) AddMembers(context context.Context, users ...Identifiable) (correlationID string, err error) {
+	return queue.postMembers(context, false, users)
+}
+
+// RemoveMembers removes users from the members of this Queue
+//
+// The users are sent in bulk requests of at most 100 users.
+func (queue Queue) RemoveMembers(context context.Context, users ...Identifiable) (correlationID string, err error) {
+	return queue.postMembers(context, true, users)
+}
+
+// SetMembersJoined joins or unjoins members of this Queue
+//
+// The members are sent in bulk requests of at most 100 members.
+func (queue Queue) SetMembersJoined(context context.Context, joined bool, members ...Identifiable) (correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	if len(members) == 0 {
+		return "", errors.ArgumentMissing.With("members")
+	}
+	type memberJoined struct {
+		ID     string `json:"id"`
+		Joined bool   `json:"joined"`
+	}
+	for start := 0; start < len(members); start += queueMembersBulkSize {
+		end := min(start+queueMembersBulkSize, len(members))
+		payload := make([]memberJoined, 0, end-start)
+		for _, member := range members[start:end] {
+			payload = append(payload, memberJoined{ID: member.GetID().String(), Joined: joined})
+		}
+		if correlationID, err = queue.client.Patch(queue.logger.ToContext(context), NewURI("/routing/queues/%s/members", queue.ID), payload, nil); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// postMembers adds or removes members of this Queue in bulk
+func (queue Queue) postMembers(context context.Context, remove bool, users []Identifiable) (correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	if len(users) == 0 {
+		return "", errors.ArgumentMissing.With("users")
+	}
+	for start := 0; start < len(users); start += queueMembersBulkSize {
+		end := min(start+queueMembersBulkSize, len(users))
+		payload := make([]EntityRef, 0, end-start)
+		for _, user := range users[start:end] {
+			payload = append(payload, EntityRef{ID: user.GetID()})
+		}
+		uri := NewURI("/routing/queues/%s/members", queue.ID).WithQuery(Query{"delete": remove})
+		if correlationID, err = queue.client.Post(queue.logger.ToContext(context), uri, payload, nil); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,312 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// QueueObservationMetric is a metric of the queue observations
+//
+// See: https://developer.genesys.cloud/analyticsdatamanagement/analytics/metrics/
+type QueueObservationMetric string
+
+// Queue observation metrics
+const (
+	QueueObservationActiveUsers         QueueObservationMetric = "oActiveUsers"         // members of the queue that are active (on queue)
+	QueueObservationAlerting            QueueObservationMetric = "oAlerting"            // interactions alerting agents
+	QueueObservationInteracting         QueueObservationMetric = "oInteracting"         // interactions connected to agents
+	QueueObservationLongestInteracting  QueueObservationMetric = "oLongestInteracting"  // the interaction connected to an agent for the longest time
+	QueueObservationLongestWaiting      QueueObservationMetric = "oLongestWaiting"      // the interaction waiting in the queue for the longest time
+	QueueObservationMemberUsers         QueueObservationMetric = "oMemberUsers"         // members of the queue
+	QueueObservationOffQueueUsers       QueueObservationMetric = "oOffQueueUsers"       // members of the queue that are off queue
+	QueueObservationOnQueueUsers        QueueObservationMetric = "oOnQueueUsers"        // members of the queue that are on queue, qualified by routing status
+	QueueObservationUserPresences       QueueObservationMetric = "oUserPresences"       // members of the queue, qualified by presence
+	QueueObservationUserRoutingStatuses QueueObservationMetric = "oUserRoutingStatuses" // members of the queue, qualified by routing status
+	QueueObservationWaiting             QueueObservationMetric = "oWaiting"             // interactions waiting in the queue
+)
+
+// QueueObservationQuery describes a query of the queue observations
+//
+// Use NewQueueObservationQuery and the With methods to build it.
+//
+// See: https://developer.genesys.cloud/analyticsdatamanagement/analytics/#post-api-v2-analytics-queues-observations-query
+type QueueObservationQuery struct {
+	Filter        *AnalyticsQueryFilter
+	Metrics       []QueueObservationMetric
+	DetailMetrics []QueueObservationMetric
+}
+
+// QueueObservations describes the observations of queues at a given time
+type QueueObservations struct {
+	Time    time.Time                `json:"-"`
+	Results []QueueObservationResult `json:"results"`
+}
+
+// QueueObservationResult describes the observations of a queue and a media type
+type QueueObservationResult struct {
+	Group map[string]string      `json:"group"` // queueId, mediaType
+	Data  []QueueObservationData `json:"data"`
+}
+
+// QueueObservationData describes the observation of a metric
+type QueueObservationData struct {
+	Metric       QueueObservationMetric `json:"metric"`
+	Qualifier    string                 `json:"qualifier,omitempty"` // e.g. the routing status of oOnQueueUsers
+	Stats        AnalyticsStats         `json:"stats"`
+	Truncated    bool                   `json:"truncated,omitempty"`
+	Observations []QueueObservation     `json:"observations,omitempty"`
+}
+
+// AnalyticsStats describes the statistics of an analytics metric
+type AnalyticsStats struct {
+	Count                 int64   `json:"count,omitempty"`
+	Max                   float64 `json:"max,omitempty"`
+	Min                   float64 `json:"min,omitempty"`
+	Sum                   float64 `json:"sum,omitempty"`
+	Current               float64 `json:"current,omitempty"`
+	Ratio                 float64 `json:"ratio,omitempty"`
+	Numerator             float64 `json:"numerator,omitempty"`
+	Denominator           float64 `json:"denominator,omitempty"`
+	Target                float64 `json:"target,omitempty"`
+	CalculatedMetricValue float64 `json:"calculatedMetricValue,omitempty"`
+}
+
+// QueueObservation describes an observed interaction or user, returned for detail metrics
+type QueueObservation struct {
+	ObservationDate time.Time `json:"observationDate"`
+	ConversationID  uuid.UUID `json:"conversationId,omitempty"`
+	SessionID       uuid.UUID `json:"sessionId,omitempty"`
+	UserID          uuid.UUID `json:"userId,omitempty"`
+	ParticipantName string    `json:"participantName,omitempty"`
+	Direction       string    `json:"direction,omitempty"`
+	AddressFrom     string    `json:"addressFrom,omitempty"`
+	AddressTo       string    `json:"addressTo,omitempty"`
+	RoutingPriority int64     `json:"routingPriority,omitempty"`
+}
+
+// QueueObservationSnapshot is emitted by PollQueueObservations
+//
+// Either Observations or Error is set.
+type QueueObservationSnapshot struct {
+	Observations  *QueueObservations
+	CorrelationID string
+	Error         error
+}
+
+// NewQueueObservationQuery creates a new QueueObservationQuery for the given queues
+//
+// The queues can be the result of FetchAll:
+//
+//	queues, _, err := gcloudcx.FetchAll[gcloudcx.Queue](context, client)
+//	query := gcloudcx.NewQueueObservationQuery(queues...)
+func NewQueueObservationQuery[Q Identifiable](queues ...Q) *QueueObservationQuery {
+	ids := make([]string, 0, len(queues))
+	for _, queue := range queues {
+		ids = append(ids, queue.GetID().String())
+	}
+	return &QueueObservationQuery{Filter: NewAnalyticsAndFilter().MatchesAny("queueId", ids...)}
+}
+
+// WithMediaTypes restricts the observations to the given media types (voice, chat, email, message, callback, ...)
+func (query *QueueObservationQuery) WithMediaTypes(mediaTypes ...string) *QueueObservationQuery {
+	if query.Filter == nil {
+		query.Filter = NewAnalyticsAndFilter()
+	}
+	query.Filter.MatchesAny("mediaType", mediaTypes...)
+	return query
+}
+
+// WithFilter sets the filter of the query
+func (query *QueueObservationQuery) WithFilter(filter *AnalyticsQueryFilter) *QueueObservationQuery {
+	query.Filter = filter
+	return query
+}
+
+// WithMetrics adds metrics to the query
+//
+// If no metric is given, Genesys Cloud returns all metrics
+func (query *QueueObservationQuery) WithMetrics(metrics ...QueueObservationMetric) *QueueObservationQuery {
+	query.Metrics = append(query.Metrics, metrics...)
+	return query
+}
+
+// WithDetailMetrics adds metrics whose observed interactions or users are returned
+func (query *QueueObservationQuery) WithDetailMetrics(metrics ...QueueObservationMetric) *QueueObservationQuery {
+	query.DetailMetrics = append(query.DetailMetrics, metrics...)
+	return query
+}
+
+// Validate validates the query
+func (query QueueObservationQuery) Validate() error {
+	if query.Filter == nil {
+		return errors.ArgumentMissing.With("filter")
+	}
+	return query.Filter.Validate()
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (query QueueObservationQuery) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Filter        *AnalyticsQueryFilter    `json:"filter"`
+		Metrics       []QueueObservationMetric `json:"metrics,omitempty"`
+		DetailMetrics []QueueObservationMetric `json:"detailMetrics,omitempty"`
+	}{
+		Filter:        query.Filter,
+		Metrics:       query.Metrics,
+		DetailMetrics: query.DetailMetrics,
+	})
+	return data, errors.JSONMarshalError.Wrap(err)
+}
+
+// QueryQueueObservations queries the current observations of queues
+func (client *Client) QueryQueueObservations(context context.Context, query *QueueObservationQuery) (*QueueObservations, string, error) {
+	if query == nil {
+		return nil, "", errors.ArgumentMissing.With("query")
+	}
+	if err := query.Validate(); err != nil {
+		return nil, "", err
+	}
+	observations := &QueueObservations{}
+	correlationID, err := client.Post(context, NewURI("/analytics/queues/observations/query"), query, observations)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	observations.Time = time.Now().UTC()
+	return observations, correlationID, nil
+}
+
+// PollQueueObservations queries the observations of queues now and then on every interval
+//
+// The snapshots are sent to the returned chan, which is closed when the context is done.
+// A failed query sends a snapshot with its Error and polling continues.
+func (client *Client) PollQueueObservations(context context.Context, query *QueueObservationQuery, interval time.Duration) (<-chan QueueObservationSnapshot, error) {
+	if query == nil {
+		return nil, errors.ArgumentMissing.With("query")
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, errors.ArgumentInvalid.With("interval", interval, "a positive duration")
+	}
+	log := client.GetLogger(context).Child("queue_observations", "poll")
+	snapshots := make(chan QueueObservationSnapshot)
+	go func() {
+		defer close(snapshots)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			observations, correlationID, err := client.QueryQueueObservations(context, query)
+			if err != nil {
+				log.Errorf("Failed to query queue observations", err)
+			}
+			select {
+			case snapshots <- QueueObservationSnapshot{Observations: observations, CorrelationID: correlationID, Error: err}:
+			case <-context.Done():
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-context.Done():
+				return
+			}
+		}
+	}()
+	return snapshots, nil
+}
+
+// Get gets the observations of a queue and a media type
+//
+// If mediaType is empty, the first result of the queue is returned
+func (observations QueueObservations) Get(queue Identifiable, mediaType string) (*QueueObservationResult, bool) {
+	for i, result := range observations.Results {
+		if result.QueueID() == queue.GetID() && (len(mediaType) == 0 || result.MediaType() == mediaType) {
+			return &observations.Results[i], true
+		}
+	}
+	return nil, false
+}
+
+// Count gets the total count of a metric for a queue across all media types
+func (observations QueueObservations) Count(queue Identifiable, metric QueueObservationMetric) (count int64) {
+	for _, result := range observations.Results {
+		if result.QueueID() == queue.GetID() {
+			count += result.Count(metric)
+		}
+	}
+	return
+}
+
+// QueueID gets the queue identifier of this result
+func (result QueueObservationResult) QueueID() uuid.UUID {
+	id, _ := uuid.Parse(result.Group["queueId"])
+	return id
+}
+
+// MediaType gets the media type of this result
+func (result QueueObservationResult) MediaType() string {
+	return result.Group["mediaType"]
+}
+
+// Metric gets the data of a metric, qualified or not
+//
+// If qualifier is empty, the first data of the metric is returned
+func (result QueueObservationResult) Metric(metric QueueObservationMetric, qualifier string) (*QueueObservationData, bool) {
+	for i, data := range result.Data {
+		if data.Metric == metric && (len(qualifier) == 0 || data.Qualifier == qualifier) {
+			return &result.Data[i], true
+		}
+	}
+	return nil, false
+}
+
+// Count gets the count of a metric, summed over all its qualifiers
+func (result QueueObservationResult) Count(metric QueueObservationMetric) (count int64) {
+	for _, data := range result.Data {
+		if data.Metric == metric {
+			count += data.Stats.Count
+		}
+	}
+	return
+}
+
+// Longest gets how long the oldest interaction of a metric like oLongestWaiting has been observed at the given time
+//
+// The calculated metric value is used if present, otherwise the oldest observation date.
+func (data QueueObservationData) Longest(at time.Time) time.Duration {
+	if data.Stats.CalculatedMetricValue > 0 {
+		return time.Duration(data.Stats.CalculatedMetricValue) * time.Millisecond
+	}
+	var oldest time.Time
+	for _, observation := range data.Observations {
+		if !observation.ObservationDate.IsZero() && (oldest.IsZero() || observation.ObservationDate.Before(oldest)) {
+			oldest = observation.ObservationDate
+		}
+	}
+	if oldest.IsZero() || at.Before(oldest) {
+		return 0
+	}
+	return at.Sub(oldest)
+}
+
+// LongestWaiting gets how long the oldest interaction has been waiting in this queue and media type
+func (result QueueObservationResult) LongestWaiting(at time.Time) time.Duration {
+	if data, found := result.Metric(QueueObservationLongestWaiting, ""); found {
+		return data.Longest(at)
+	}
+	return 0
+}
+
+// LongestInteracting gets how long the oldest interaction has been connected to an agent in this queue and media type
+func (result QueueObservationResult) LongestInteracting(at time.Time) time.Duration {
+	if data, found := result.Metric(QueueObservationLongestInteracting, ""); found {
+		return data.Longest(at)
+	}
+	return 0
+}
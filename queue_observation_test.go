@@ -0,0 +1,197 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type QueueObservationSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestQueueObservationSuite(t *testing.T) {
+	suite.Run(t, new(QueueObservationSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *QueueObservationSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *QueueObservationSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *QueueObservationSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *QueueObservationSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *QueueObservationSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *QueueObservationSuite) TestCanMarshalQuery() {
+	queues := []*gcloudcx.Queue{
+		{ID: uuid.MustParse("06ffcd2e-1ada-412e-a5f5-30d7853246dd")},
+		{ID: uuid.MustParse("3b6e5c9a-8f0d-4b4c-9a57-2f0b1c6d7e8f")},
+	}
+	query := gcloudcx.NewQueueObservationQuery(queues...).
+		WithMediaTypes("voice").
+		WithMetrics(gcloudcx.QueueObservationWaiting, gcloudcx.QueueObservationOnQueueUsers).
+		WithDetailMetrics(gcloudcx.QueueObservationLongestWaiting)
+	suite.Require().NoError(query.Validate())
+
+	data, err := json.Marshal(query)
+	suite.Require().NoErrorf(err, "Failed to marshal query. %s", err)
+	expected := `{
+		"filter": {
+			"type": "and",
+			"clauses": [{"type": "or", "predicates": [
+				{"type": "dimension", "dimension": "queueId", "operator": "matches", "value": "06ffcd2e-1ada-412e-a5f5-30d7853246dd"},
+				{"type": "dimension", "dimension": "queueId", "operator": "matches", "value": "3b6e5c9a-8f0d-4b4c-9a57-2f0b1c6d7e8f"}
+			]}],
+			"predicates": [{"type": "dimension", "dimension": "mediaType", "operator": "matches", "value": "voice"}]
+		},
+		"metrics": ["oWaiting", "oOnQueueUsers"],
+		"detailMetrics": ["oLongestWaiting"]
+	}`
+	suite.Assert().JSONEq(expected, string(data))
+}
+
+func (suite *QueueObservationSuite) TestShouldNotValidateInvalidFilter() {
+	err := gcloudcx.NewQueueObservationQuery[gcloudcx.Identifiable]().Validate()
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+
+	err = (&gcloudcx.AnalyticsQueryFilter{Type: "xor"}).Matches("queueId", "1").WithClause("nand").Validate()
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+}
+
+func (suite *QueueObservationSuite) TestCanQueryObservations() {
+	queueID := uuid.New()
+	oldest := time.Now().UTC().Add(-90 * time.Second)
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/analytics/queues/observations/query": map[string]any{
+			"results": []map[string]any{
+				{
+					"group": map[string]any{"queueId": queueID, "mediaType": "voice"},
+					"data": []map[string]any{
+						{"metric": "oWaiting", "stats": map[string]any{"count": 3}},
+						{"metric": "oOnQueueUsers", "qualifier": "IDLE", "stats": map[string]any{"count": 2}},
+						{"metric": "oOnQueueUsers", "qualifier": "INTERACTING", "stats": map[string]any{"count": 4}},
+						{"metric": "oLongestWaiting", "stats": map[string]any{"count": 1}, "observations": []map[string]any{{"observationDate": oldest.Format(time.RFC3339Nano), "conversationId": uuid.New()}}},
+						{"metric": "oLongestInteracting", "stats": map[string]any{"count": 1, "calculatedMetricValue": 120000}},
+					},
+				},
+				{
+					"group": map[string]any{"queueId": queueID, "mediaType": "chat"},
+					"data":  []map[string]any{{"metric": "oWaiting", "stats": map[string]any{"count": 1}}},
+				},
+			},
+		},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	queue := gcloudcx.Queue{ID: queueID}
+
+	observations, _, err := client.QueryQueueObservations(context.Background(), gcloudcx.NewQueueObservationQuery(queue))
+	suite.Require().NoErrorf(err, "Failed to query observations. %s", err)
+	suite.Assert().Equal(int64(4), observations.Count(queue, gcloudcx.QueueObservationWaiting))
+
+	voice, found := observations.Get(queue, "voice")
+	suite.Require().True(found)
+	suite.Assert().Equal(int64(3), voice.Count(gcloudcx.QueueObservationWaiting))
+	suite.Assert().Equal(int64(6), voice.Count(gcloudcx.QueueObservationOnQueueUsers))
+	idle, found := voice.Metric(gcloudcx.QueueObservationOnQueueUsers, gcloudcx.RoutingStatusIdle)
+	suite.Require().True(found)
+	suite.Assert().Equal(int64(2), idle.Stats.Count)
+	suite.Assert().InDelta(90*time.Second, voice.LongestWaiting(observations.Time), float64(5*time.Second))
+	suite.Assert().Equal(2*time.Minute, voice.LongestInteracting(observations.Time))
+
+	_, found = observations.Get(gcloudcx.EntityRef{ID: uuid.New()}, "")
+	suite.Assert().False(found)
+}
+
+func (suite *QueueObservationSuite) TestCanPollObservations() {
+	queueID := uuid.New()
+	polled := 0
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/analytics/queues/observations/query": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			polled++
+			core.RespondWithJSON(w, http.StatusOK, map[string]any{
+				"results": []map[string]any{{"group": map[string]any{"queueId": queueID, "mediaType": "voice"}, "data": []map[string]any{{"metric": "oWaiting", "stats": map[string]any{"count": polled}}}}},
+			})
+		}),
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	queue := gcloudcx.Queue{ID: queueID}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	snapshots, err := client.PollQueueObservations(ctx, gcloudcx.NewQueueObservationQuery(queue).WithMetrics(gcloudcx.QueueObservationWaiting), 10*time.Millisecond)
+	suite.Require().NoErrorf(err, "Failed to poll observations. %s", err)
+
+	for expected := int64(1); expected <= 3; expected++ {
+		snapshot := <-snapshots
+		suite.Require().NoErrorf(snapshot.Error, "Failed to poll observations. %s", snapshot.Error)
+		suite.Assert().Equal(expected, snapshot.Observations.Count(queue, gcloudcx.QueueObservationWaiting))
+	}
+	cancel()
+	for range snapshots {
+	}
+
+	_, err = client.PollQueueObservations(context.Background(), gcloudcx.NewQueueObservationQuery(queue), 0)
+	suite.Assert().ErrorIs(err, errors.ArgumentInvalid)
+}
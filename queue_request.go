@@ -0,0 +1,339 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+// QueueRequest describes a request to create or update a Queue
+//
+// Use NewQueueRequest and the With methods to build it, or Queue.Request to update an existing Queue.
+//
+// Genesys Cloud replaces the whole Queue on updates: a request built with NewQueueRequest resets
+// every setting it does not carry. A request built with Queue.Request keeps the settings of the Queue,
+// including the ones this library does not model.
+//
+// See: https://developer.genesys.cloud/routing/routing/#post-api-v2-routing-queues
+type QueueRequest struct {
+	Name                  string
+	Description           string
+	Division              Identifiable
+	MediaSettings         MediaSettings
+	ACWSettings           *ACWSettings
+	SkillEvaluationMethod string
+	Bullseye              *QueueBullseye
+	DefaultScripts        QueueScripts
+	AutoAnswerOnly        bool
+	OutboundEmailAddress  *QueueEmailAddress
+	base                  json.RawMessage // the Queue this request was built from
+}
+
+// NewQueueRequest creates a new QueueRequest with the given name
+func NewQueueRequest(name string) *QueueRequest {
+	return &QueueRequest{Name: name}
+}
+
+// Request creates a QueueRequest from this Queue
+//
+// The request can be modified with its With methods before updating the Queue.
+func (queue Queue) Request() *QueueRequest {
+	request := &QueueRequest{
+		Name:                  queue.Name,
+		Description:           queue.Description,
+		SkillEvaluationMethod: queue.SkillEvaluationMethod,
+		AutoAnswerOnly:        queue.AutoAnswerOnly,
+		Bullseye:              queue.Bullseye,
+		OutboundEmailAddress:  queue.OutboundEmailAddress,
+		base:                  queue.raw,
+	}
+	if queue.Division != nil {
+		request.Division = queue.Division
+	}
+	if len(queue.MediaSettings) > 0 {
+		request.MediaSettings = make(MediaSettings, len(queue.MediaSettings))
+		for media, setting := range queue.MediaSettings {
+			request.MediaSettings[media] = setting
+		}
+	}
+	settings := queue.ACWSettings
+	request.ACWSettings = &settings
+	if len(queue.DefaultScripts) > 0 {
+		request.DefaultScripts = make(QueueScripts, len(queue.DefaultScripts))
+		for media, script := range queue.DefaultScripts {
+			request.DefaultScripts[media] = script
+		}
+	}
+	return request
+}
+
+// WithName sets the name of the queue
+func (request *QueueRequest) WithName(name string) *QueueRequest {
+	request.Name = name
+	return request
+}
+
+// WithDescription sets the description of the queue
+func (request *QueueRequest) WithDescription(description string) *QueueRequest {
+	request.Description = description
+	return request
+}
+
+// WithDivision sets the division of the queue
+func (request *QueueRequest) WithDivision(division Identifiable) *QueueRequest {
+	request.Division = division
+	return request
+}
+
+// WithMediaSetting sets the settings of a media (call, chat, email, message, ...) of the queue
+func (request *QueueRequest) WithMediaSetting(media string, setting MediaSetting) *QueueRequest {
+	if request.MediaSettings == nil {
+		request.MediaSettings = MediaSettings{}
+	}
+	request.MediaSettings[media] = setting
+	return request
+}
+
+// WithACWSettings sets the After Call Work settings of the queue
+func (request *QueueRequest) WithACWSettings(settings ACWSettings) *QueueRequest {
+	request.ACWSettings = &settings
+	return request
+}
+
+// WithSkillEvaluationMethod sets the skill evaluation method of the queue (NONE, BEST, ALL)
+func (request *QueueRequest) WithSkillEvaluationMethod(method string) *QueueRequest {
+	request.SkillEvaluationMethod = method
+	return request
+}
+
+// WithBullseyeRings sets the bullseye rings of the queue
+func (request *QueueRequest) WithBullseyeRings(rings ...QueueBullseyeRing) *QueueRequest {
+	request.Bullseye = &QueueBullseye{Rings: rings}
+	return request
+}
+
+// WithDefaultScript sets the default script of a media of the queue
+func (request *QueueRequest) WithDefaultScript(media string, script Identifiable) *QueueRequest {
+	if request.DefaultScripts == nil {
+		request.DefaultScripts = QueueScripts{}
+	}
+	request.DefaultScripts[media] = DomainEntityRef{ID: script.GetID()}
+	return request
+}
+
+// WithOutboundEmailAddress sets the address used to send emails from the queue
+func (request *QueueRequest) WithOutboundEmailAddress(address *QueueEmailAddress) *QueueRequest {
+	request.OutboundEmailAddress = address
+	return request
+}
+
+// WithAutoAnswerOnly tells if the queue is for auto-answer agents only
+func (request *QueueRequest) WithAutoAnswerOnly(autoAnswerOnly bool) *QueueRequest {
+	request.AutoAnswerOnly = autoAnswerOnly
+	return request
+}
+
+// Validate validates the queue request
+func (request QueueRequest) Validate() error {
+	var merr errors.MultiError
+	if len(request.Name) == 0 {
+		merr.Append(errors.ArgumentMissing.With("name"))
+	}
+	switch request.SkillEvaluationMethod {
+	case "", SkillEvaluationNone, SkillEvaluationBest, SkillEvaluationAll:
+	default:
+		merr.Append(errors.ArgumentInvalid.With("skillEvaluationMethod", request.SkillEvaluationMethod, "NONE, BEST, ALL"))
+	}
+	if request.Bullseye != nil {
+		if err := request.Bullseye.Validate(); err != nil {
+			merr.Append(err)
+		}
+	}
+	for media, setting := range request.MediaSettings {
+		if setting.AlertingTimeout < 0 {
+			merr.Append(errors.ArgumentInvalid.With("mediaSettings."+media+".alertingTimeout", setting.AlertingTimeout, "a positive duration"))
+		}
+	}
+	return merr.AsError()
+}
+
+// MarshalJSON marshals this into JSON
+//
+// If the request was built with Queue.Request, its fields are merged into the payload of the Queue
+// so the settings that are not modeled are sent back unchanged.
+//
+// implements json.Marshaler
+func (request QueueRequest) MarshalJSON() ([]byte, error) {
+	var division *EntityRef
+	if request.Division != nil {
+		division = &EntityRef{ID: request.Division.GetID()}
+	}
+	var outboundEmailAddress *queueEmailAddressRef
+	if request.OutboundEmailAddress != nil {
+		outboundEmailAddress = &queueEmailAddressRef{Domain: EmailDomainRef{ID: request.OutboundEmailAddress.Domain.ID}}
+		if request.OutboundEmailAddress.Route != nil {
+			outboundEmailAddress.Route = &EntityRef{ID: request.OutboundEmailAddress.Route.ID}
+		}
+	}
+	data, err := json.Marshal(struct {
+		Name                  string                `json:"name"`
+		Description           string                `json:"description,omitempty"`
+		Division              *EntityRef            `json:"division,omitempty"`
+		MediaSettings         MediaSettings         `json:"mediaSettings,omitempty"`
+		ACWSettings           *ACWSettings          `json:"acwSettings,omitempty"`
+		SkillEvaluationMethod string                `json:"skillEvaluationMethod,omitempty"`
+		Bullseye              *QueueBullseye        `json:"bullseye,omitempty"`
+		DefaultScripts        QueueScripts          `json:"defaultScripts,omitempty"`
+		AutoAnswerOnly        bool                  `json:"autoAnswerOnly"`
+		OutboundEmailAddress  *queueEmailAddressRef `json:"outboundEmailAddress,omitempty"`
+	}{
+		Name:                  request.Name,
+		Description:           request.Description,
+		Division:              division,
+		MediaSettings:         request.MediaSettings,
+		ACWSettings:           request.ACWSettings,
+		SkillEvaluationMethod: request.SkillEvaluationMethod,
+		Bullseye:              request.Bullseye,
+		DefaultScripts:        request.DefaultScripts,
+		AutoAnswerOnly:        request.AutoAnswerOnly,
+		OutboundEmailAddress:  outboundEmailAddress,
+	})
+	if err != nil || len(request.base) == 0 {
+		return data, errors.JSONMarshalError.Wrap(err)
+	}
+	return mergeQueuePayload(request.base, data)
+}
+
+// queueEmailAddressRef is the outbound email address of a QueueRequest, sent with references only
+type queueEmailAddressRef struct {
+	Domain EmailDomainRef `json:"domain"`
+	Route  *EntityRef     `json:"route,omitempty"`
+}
+
+// queueRequestFields are the fields of the Queue payload that are modeled by QueueRequest
+var queueRequestFields = []string{
+	"name", "description", "division", "mediaSettings", "acwSettings", "skillEvaluationMethod",
+	"bullseye", "defaultScripts", "autoAnswerOnly", "outboundEmailAddress",
+}
+
+// mediaSettingFields are the fields of a media setting that are modeled by MediaSetting
+var mediaSettingFields = []string{"alertingTimeoutSeconds", "serviceLevel"}
+
+// bullseyeRingFields are the fields of a bullseye ring that are modeled by QueueBullseyeRing
+var bullseyeRingFields = []string{"expansionTimeoutSeconds", "skillsToRemove"}
+
+// mergeQueuePayload merges the fields of a QueueRequest payload into the payload of a Queue
+//
+// The modeled fields of the Queue are replaced, even when they are not in the request (to clear them),
+// the other fields of the Queue are kept.
+// Media settings and bullseye rings are merged key by key, so their fields that are not modeled
+// (auto answer, alerting tones, member groups, ...) are kept too.
+func mergeQueuePayload(base, data []byte) ([]byte, error) {
+	var original, fields map[string]json.RawMessage
+	if err := json.Unmarshal(base, &original); err != nil {
+		return nil, errors.JSONUnmarshalError.Wrap(err)
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.JSONUnmarshalError.Wrap(err)
+	}
+	if settings, found := fields["mediaSettings"]; found {
+		merged, err := mergeMediaSettingsPayload(original["mediaSettings"], settings)
+		if err != nil {
+			return nil, err
+		}
+		fields["mediaSettings"] = merged
+	}
+	if bullseye, found := fields["bullseye"]; found {
+		merged, err := mergeBullseyePayload(original["bullseye"], bullseye)
+		if err != nil {
+			return nil, err
+		}
+		fields["bullseye"] = merged
+	}
+	return mergeJSONObject(base, fields, queueRequestFields)
+}
+
+// mergeMediaSettingsPayload merges the media settings of a QueueRequest into the ones of a Queue
+//
+// Media that are not in the request are removed.
+func mergeMediaSettingsPayload(base, data json.RawMessage) (json.RawMessage, error) {
+	var original, settings map[string]json.RawMessage
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &original); err != nil {
+			return nil, errors.JSONUnmarshalError.Wrap(err)
+		}
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, errors.JSONUnmarshalError.Wrap(err)
+	}
+	for media, setting := range settings {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(setting, &fields); err != nil {
+			return nil, errors.JSONUnmarshalError.Wrap(err)
+		}
+		merged, err := mergeJSONObject(original[media], fields, mediaSettingFields)
+		if err != nil {
+			return nil, err
+		}
+		settings[media] = merged
+	}
+	merged, err := json.Marshal(settings)
+	return merged, errors.JSONMarshalError.Wrap(err)
+}
+
+// mergeBullseyePayload merges the bullseye of a QueueRequest into the one of a Queue
+//
+// Rings are merged by position, rings that are not in the request are removed.
+func mergeBullseyePayload(base, data json.RawMessage) (json.RawMessage, error) {
+	var original, bullseye struct {
+		Rings []json.RawMessage `json:"rings"`
+	}
+	if len(base) > 0 {
+		if err := json.Unmarshal(base, &original); err != nil {
+			return nil, errors.JSONUnmarshalError.Wrap(err)
+		}
+	}
+	if err := json.Unmarshal(data, &bullseye); err != nil {
+		return nil, errors.JSONUnmarshalError.Wrap(err)
+	}
+	for index, ring := range bullseye.Rings {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(ring, &fields); err != nil {
+			return nil, errors.JSONUnmarshalError.Wrap(err)
+		}
+		var originalRing json.RawMessage
+		if index < len(original.Rings) {
+			originalRing = original.Rings[index]
+		}
+		merged, err := mergeJSONObject(originalRing, fields, bullseyeRingFields)
+		if err != nil {
+			return nil, err
+		}
+		bullseye.Rings[index] = merged
+	}
+	rings, err := json.Marshal(bullseye.Rings)
+	if err != nil {
+		return nil, errors.JSONMarshalError.Wrap(err)
+	}
+	return mergeJSONObject(base, map[string]json.RawMessage{"rings": rings}, []string{"rings"})
+}
+
+// mergeJSONObject replaces the modeled fields of a JSON object with the given fields
+//
+// The modeled fields that are not given are removed, the other fields of the object are kept.
+func mergeJSONObject(base json.RawMessage, fields map[string]json.RawMessage, modeled []string) (json.RawMessage, error) {
+	merged := map[string]json.RawMessage{}
+	if len(base) > 0 && string(base) != "null" {
+		if err := json.Unmarshal(base, &merged); err != nil {
+			return nil, errors.JSONUnmarshalError.Wrap(err)
+		}
+	}
+	for _, field := range modeled {
+		delete(merged, field)
+	}
+	for field, value := range fields {
+		merged[field] = value
+	}
+	data, err := json.Marshal(merged)
+	return data, errors.JSONMarshalError.Wrap(err)
+}
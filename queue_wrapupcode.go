@@ -0,0 +1,55 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+// FetchWrapupCodes fetches the Wrap-up Codes assigned to this Queue
+func (queue Queue) FetchWrapupCodes(context context.Context) (codes []*WrapupCode, correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	entities, correlationID, err := queue.client.FetchEntities(context, NewURI("/routing/queues/%s/wrapupcodes", queue.ID))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	codes = make([]*WrapupCode, 0, len(entities))
+	for _, entity := range entities {
+		code := WrapupCode{}
+		if err = json.Unmarshal(entity, &code); err != nil {
+			return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+		}
+		code.Initialize(queue.client, queue.client.Logger)
+		codes = append(codes, &code)
+	}
+	return codes, correlationID, nil
+}
+
+// AddWrapupCodes assigns Wrap-up Codes to this Queue
+func (queue Queue) AddWrapupCodes(context context.Context, codes ...Identifiable) (correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	if len(codes) == 0 {
+		return "", errors.ArgumentMissing.With("codes")
+	}
+	payload := make([]EntityRef, 0, len(codes))
+	for _, code := range codes {
+		payload = append(payload, EntityRef{ID: code.GetID()})
+	}
+	return queue.client.Post(context, NewURI("/routing/queues/%s/wrapupcodes", queue.ID), payload, nil)
+}
+
+// RemoveWrapupCode removes a Wrap-up Code from this Queue
+func (queue Queue) RemoveWrapupCode(context context.Context, code Identifiable) (correlationID string, err error) {
+	if err = queue.checkInitialized(); err != nil {
+		return
+	}
+	if code == nil {
+		return "", errors.ArgumentMissing.With("code")
+	}
+	return queue.client.Delete(context, NewURI("/routing/queues/%s/wrapupcodes/%s", queue.ID, code.GetID()), nil)
+}
@@ -1,6 +1,7 @@
 package gcloudcx
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// RecordingAnnotation describes an annotation of a Recording
+//
+// Annotations are created by the system (hold, pause, ...) or by users (bookmark).
 type RecordingAnnotation struct {
 	ID                uuid.UUID             `json:"id"`
 	Name              string                `json:"name"`
@@ -27,6 +31,125 @@ type RecordingAnnotation struct {
 	SelfURI           string                `json:"selfUri"`
 }
 
+// RecordingAnnotation types
+const (
+	RecordingAnnotationTypeBookmark = "bookmark"
+	RecordingAnnotationTypeHold     = "hold"
+	RecordingAnnotationTypePause    = "pause"
+)
+
+// NewRecordingBookmark creates a new bookmark annotation at the given location of a Recording
+func NewRecordingBookmark(name string, location, duration time.Duration, description string) RecordingAnnotation {
+	return RecordingAnnotation{
+		Name:        name,
+		Type:        RecordingAnnotationTypeBookmark,
+		Description: description,
+		Location:    location,
+		Duration:    duration,
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (annotation RecordingAnnotation) GetID() uuid.UUID {
+	return annotation.ID
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (annotation RecordingAnnotation) String() string {
+	if len(annotation.Name) > 0 {
+		return annotation.Name
+	}
+	return annotation.ID.String()
+}
+
+// recordingAnnotationRequest is the payload sent to create or update an annotation
+//
+// Only the fields that can be set by users are sent.
+type recordingAnnotationRequest struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Location    int64  `json:"location"`
+	Duration    int64  `json:"durationMs"`
+}
+
+// newRecordingAnnotationRequest creates the payload to create or update an annotation
+func newRecordingAnnotationRequest(annotation RecordingAnnotation) recordingAnnotationRequest {
+	return recordingAnnotationRequest{
+		ID:          identifiableID(annotation),
+		Name:        annotation.Name,
+		Type:        annotation.Type,
+		Description: annotation.Description,
+		Location:    annotation.Location.Milliseconds(),
+		Duration:    annotation.Duration.Milliseconds(),
+	}
+}
+
+// CreateAnnotation creates an annotation on this Recording
+func (recording Recording) CreateAnnotation(context context.Context, annotation RecordingAnnotation) (*RecordingAnnotation, string, error) {
+	if err := recording.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if len(annotation.Name) == 0 {
+		return nil, "", errors.ArgumentMissing.With("name")
+	}
+	if annotation.Location < 0 || annotation.Duration < 0 {
+		return nil, "", errors.ArgumentInvalid.With("location", annotation.Location)
+	}
+	created := RecordingAnnotation{}
+	correlationID, err := recording.client.Post(
+		context,
+		NewURI("/conversations/%s/recordings/%s/annotations", recording.ConversationID, recording.ID),
+		newRecordingAnnotationRequest(annotation),
+		&created,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return &created, correlationID, nil
+}
+
+// UpdateAnnotation updates an annotation of this Recording
+func (recording Recording) UpdateAnnotation(context context.Context, annotation RecordingAnnotation) (*RecordingAnnotation, string, error) {
+	if err := recording.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if annotation.ID == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("id")
+	}
+	updated := RecordingAnnotation{}
+	correlationID, err := recording.client.Put(
+		context,
+		NewURI("/conversations/%s/recordings/%s/annotations/%s", recording.ConversationID, recording.ID, annotation.ID),
+		newRecordingAnnotationRequest(annotation),
+		&updated,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return &updated, correlationID, nil
+}
+
+// DeleteAnnotation deletes an annotation of this Recording
+func (recording Recording) DeleteAnnotation(context context.Context, annotation Identifiable) (correlationID string, err error) {
+	if err = recording.checkInitialized(); err != nil {
+		return
+	}
+	if annotation == nil {
+		return "", errors.ArgumentMissing.With("annotation")
+	}
+	return recording.client.Delete(
+		context,
+		NewURI("/conversations/%s/recordings/%s/annotations/%s", recording.ConversationID, recording.ID, annotation.GetID()),
+		nil,
+	)
+}
+
 // UnmarshalJSON unmarshals the annotation from JSON
 //
 // Implements json.Unmarshaler
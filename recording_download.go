@@ -0,0 +1,208 @@
+package gcloudcx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-request"
+)
+
+// RecordingFormat describes the format a Recording is transcoded to before being downloaded
+type RecordingFormat string
+
+// Recording formats
+const (
+	RecordingFormatWAV     RecordingFormat = "WAV"
+	RecordingFormatWEBM    RecordingFormat = "WEBM"
+	RecordingFormatOGGOpus RecordingFormat = "OGG_OPUS"
+	RecordingFormatMP3     RecordingFormat = "MP3"
+	RecordingFormatNone    RecordingFormat = "NONE" // the media is downloaded as recorded
+)
+
+// DefaultRecordingRestoreDays is the number of days archived recordings are restored for before being downloaded
+const DefaultRecordingRestoreDays = 1
+
+const (
+	recordingPollAttempts     = 120
+	recordingDefaultPollDelay = 5 * time.Second
+)
+
+// Validate validates this RecordingFormat
+func (format RecordingFormat) Validate() error {
+	switch format {
+	case RecordingFormatWAV, RecordingFormatWEBM, RecordingFormatOGGOpus, RecordingFormatMP3, RecordingFormatNone:
+		return nil
+	}
+	return errors.ArgumentInvalid.With("format", format)
+}
+
+// Restore restores this archived Recording for the given number of days
+func (recording Recording) Restore(context context.Context, days int) (correlationID string, err error) {
+	if err = recording.checkInitialized(); err != nil {
+		return
+	}
+	if days < 1 {
+		return "", errors.ArgumentInvalid.With("days", days)
+	}
+	return recording.client.Put(
+		context,
+		NewURI("/conversations/%s/recordings/%s?restoreDays=%d", recording.ConversationID, recording.ID, days),
+		struct {
+			ID             string `json:"id"`
+			ConversationID string `json:"conversationId"`
+		}{
+			ID:             recording.ID.String(),
+			ConversationID: recording.ConversationID.String(),
+		},
+		nil,
+	)
+}
+
+// Download downloads the media of this Recording in the given format and writes it to the given writer
+//
+// Archived recordings are restored first. While Genesys Cloud restores or transcodes the media,
+// the recording is polled again after its estimated transcode time.
+//
+// The media is streamed to the writer, and its size is checked against the recording's OutputSizeInBytes when known.
+func (recording Recording) Download(context context.Context, format RecordingFormat, writer io.Writer) (correlationID string, err error) {
+	if err = recording.checkInitialized(); err != nil {
+		return
+	}
+	if writer == nil {
+		return "", errors.ArgumentMissing.With("writer")
+	}
+	if err = format.Validate(); err != nil {
+		return
+	}
+	log := recording.client.GetLogger(context).Child("recording", "download", "recording", recording.ID, "format", format)
+
+	if recording.FileState == "ARCHIVED" {
+		log.Infof("Recording %s is archived, restoring it for %d days", recording.ID, DefaultRecordingRestoreDays)
+		if correlationID, err = recording.Restore(context, DefaultRecordingRestoreDays); err != nil {
+			return correlationID, err
+		}
+	}
+
+	delay := recording.EstimatedTranscodeTime
+	for attempt := 1; attempt <= recordingPollAttempts; attempt++ {
+		var fetched Recording
+		correlationID, err = recording.client.Get(
+			log.ToContext(context),
+			NewURI("/conversations/%s/recordings/%s?formatId=%s&download=true", recording.ConversationID, recording.ID, format),
+			&fetched,
+		)
+		if err != nil {
+			return correlationID, err
+		}
+		if mediaURI := fetched.downloadURI(); mediaURI != nil && fetched.FileState != "RESTORING" {
+			expected := fetched.OutputSizeInBytes
+			if expected == 0 {
+				expected = recording.OutputSizeInBytes
+			}
+			return correlationID, recording.streamMedia(context, mediaURI, writer, expected)
+		}
+		if fetched.EstimatedTranscodeTime > 0 {
+			delay = fetched.EstimatedTranscodeTime
+		}
+		if delay <= 0 {
+			delay = recordingDefaultPollDelay
+		}
+		log.Debugf("Recording %s is not available yet (state: %s), polling again in %s", recording.ID, fetched.FileState, delay)
+		select {
+		case <-context.Done():
+			return correlationID, errors.WithStack(context.Err())
+		case <-time.After(delay):
+		}
+	}
+	return correlationID, errors.Timeout.With("recording download")
+}
+
+// DownloadRecordings downloads the media of all the recordings of this conversation in the given format
+//
+// open is called for each recording to get the writer its media is written to, the writer is closed after the download.
+// Recordings without media (chat, messaging, email) are skipped, use FetchTranscript for those.
+func (conversation Conversation) DownloadRecordings(context context.Context, format RecordingFormat, open func(recording Recording) (io.WriteCloser, error)) (correlationID string, err error) {
+	if open == nil {
+		return "", errors.ArgumentMissing.With("open")
+	}
+	recordings, correlationID, err := conversation.FetchRecordings(context)
+	if err != nil {
+		return correlationID, err
+	}
+	errs := errors.MultiError{}
+	for _, recording := range recordings {
+		if recording.Media != "audio" && recording.Media != "screen" {
+			continue
+		}
+		writer, err := open(recording)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+		correlationID, err = recording.Download(context, format, writer)
+		errs.Append(err)
+		errs.Append(writer.Close())
+	}
+	return correlationID, errs.AsError()
+}
+
+// downloadURI gets the URI of the first media of this Recording
+func (recording Recording) downloadURI() *RecordingMediaURI {
+	keys := make([]string, 0, len(recording.MediaURIs))
+	for key, mediaURI := range recording.MediaURIs {
+		if mediaURI != nil && mediaURI.URI != nil {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return recording.MediaURIs[keys[0]]
+}
+
+// streamMedia streams the media at the given URI to the writer
+//
+// The media URI is pre-signed, so the access token of the Client is not sent.
+func (recording Recording) streamMedia(context context.Context, mediaURI *RecordingMediaURI, writer io.Writer, expected int64) error {
+	log := recording.client.GetLogger(context).Child("recording", "stream", "recording", recording.ID)
+	counter := &countingWriter{Writer: writer}
+	_, err := request.Send(&request.Options{
+		Context:  context,
+		Method:   http.MethodGet,
+		URL:      mediaURI.URI,
+		Proxy:    recording.client.Proxy,
+		Timeout:  10 * time.Minute, // Large recordings take a while to stream
+		Attempts: 1,                // Retrying would write the beginning of the media twice
+		Logger:   log,
+	}, counter)
+	if err != nil {
+		return err
+	}
+	log.Debugf("Downloaded %d bytes", counter.Count)
+	if expected > 0 && counter.Count != expected {
+		return errors.Invalid.With("size", counter.Count, expected)
+	}
+	return nil
+}
+
+// checkInitialized checks if this Recording can send requests
+func (recording Recording) checkInitialized() error {
+	return checkInitialized(recording.client, "Recording", recording.ID)
+}
+
+// countingWriter counts the bytes written to its Writer
+type countingWriter struct {
+	io.Writer
+	Count int64
+}
+
+func (writer *countingWriter) Write(data []byte) (int, error) {
+	written, err := writer.Writer.Write(data)
+	writer.Count += int64(written)
+	return written, err
+}
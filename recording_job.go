@@ -0,0 +1,226 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// RecordingJob describes a bulk job that exports, deletes, or archives recordings
+//
+// See: https://developer.genesys.cloud/analyticsdatamanagement/recording/recording-apis
+type RecordingJob struct {
+	ID                       uuid.UUID         `json:"id"`
+	State                    RecordingJobState `json:"state"`
+	DateCreated              time.Time         `json:"dateCreated"`
+	TotalConversations       int               `json:"totalConversations"`
+	TotalRecordings          int               `json:"totalRecordings"`
+	TotalSkippedRecordings   int               `json:"totalSkippedRecordings"`
+	TotalFailedRecordings    int               `json:"totalFailedRecordings"`
+	TotalProcessedRecordings int               `json:"totalProcessedRecordings"`
+	PercentProgress          int               `json:"percentProgress"`
+	ErrorMessage             string            `json:"errorMessage,omitempty"`
+	FailedRecordingsURI      URI               `json:"failedRecordings,omitempty"`
+	User                     *DomainEntityRef  `json:"user,omitempty"`
+	SelfURI                  URI               `json:"selfUri,omitempty"`
+	client                   *Client           `json:"-"`
+	logger                   *logger.Logger    `json:"-"`
+}
+
+// RecordingJobState describes the state of a RecordingJob
+type RecordingJobState string
+
+// Recording job states
+const (
+	RecordingJobStatePending    RecordingJobState = "PENDING"    // the query is being run
+	RecordingJobStateReady      RecordingJobState = "READY"      // the job can be executed or cancelled
+	RecordingJobStateProcessing RecordingJobState = "PROCESSING" // the action is being performed
+	RecordingJobStateFulfilled  RecordingJobState = "FULFILLED"
+	RecordingJobStateCancelled  RecordingJobState = "CANCELLED"
+	RecordingJobStateFailed     RecordingJobState = "FAILED"
+)
+
+// RecordingJobProgress describes the progress of a RecordingJob
+type RecordingJobProgress struct {
+	State              RecordingJobState
+	TotalConversations int
+	TotalRecordings    int
+	Processed          int
+	Skipped            int
+	Failed             int
+	Percent            int
+}
+
+// FailedRecording describes a recording a RecordingJob could not process
+type FailedRecording struct {
+	ConversationID uuid.UUID `json:"conversationId"`
+	RecordingID    uuid.UUID `json:"recordingId"`
+}
+
+// IsFinal tells if the RecordingJob will not change anymore
+func (state RecordingJobState) IsFinal() bool {
+	return state == RecordingJobStateFulfilled || state == RecordingJobStateCancelled || state == RecordingJobStateFailed
+}
+
+// CreateRecordingJob creates a RecordingJob
+//
+// The job starts in the PENDING state while its query runs, use Wait to know when it is READY and Execute to run it.
+func (client *Client) CreateRecordingJob(context context.Context, request *RecordingJobRequest) (job *RecordingJob, correlationID string, err error) {
+	if request == nil {
+		return nil, "", errors.ArgumentMissing.With("request")
+	}
+	if err = request.Validate(); err != nil {
+		return nil, "", err
+	}
+	job = &RecordingJob{}
+	if correlationID, err = client.Post(context, NewURI("/recording/jobs"), request, job); err != nil {
+		return nil, correlationID, err
+	}
+	job.Initialize(client, client.Logger)
+	return job, correlationID, nil
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (job *RecordingJob) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			job.ID = parameter
+		case *Client:
+			job.client = parameter
+		case *logger.Logger:
+			job.logger = parameter.Child("recordingjob", "recordingjob", "id", job.ID)
+		}
+	}
+	if job.logger == nil {
+		job.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (job RecordingJob) GetID() uuid.UUID {
+	return job.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (job RecordingJob) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/recording/jobs/%s", ids[0])
+	}
+	if job.ID != uuid.Nil {
+		return NewURI("/api/v2/recording/jobs/%s", job.ID)
+	}
+	return URI("/api/v2/recording/jobs/")
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (job RecordingJob) String() string {
+	return job.ID.String()
+}
+
+// Progress gets the progress of this RecordingJob
+func (job RecordingJob) Progress() RecordingJobProgress {
+	return RecordingJobProgress{
+		State:              job.State,
+		TotalConversations: job.TotalConversations,
+		TotalRecordings:    job.TotalRecordings,
+		Processed:          job.TotalProcessedRecordings,
+		Skipped:            job.TotalSkippedRecordings,
+		Failed:             job.TotalFailedRecordings,
+		Percent:            job.PercentProgress,
+	}
+}
+
+// Wait polls this RecordingJob until it is READY or in a final state
+//
+// progress, if not nil, is called after each poll. The last fetched RecordingJob is returned.
+func (job RecordingJob) Wait(context context.Context, interval time.Duration, progress func(RecordingJobProgress)) (*RecordingJob, string, error) {
+	if err := job.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if interval <= 0 {
+		return nil, "", errors.ArgumentInvalid.With("interval", interval)
+	}
+	for {
+		current, correlationID, err := Fetch[RecordingJob](context, job.client, job.ID, job.logger)
+		if err != nil {
+			return nil, correlationID, err
+		}
+		if progress != nil {
+			progress(current.Progress())
+		}
+		if current.State == RecordingJobStateReady || current.State.IsFinal() {
+			return current, correlationID, nil
+		}
+		job.logger.Debugf("Recording job %s is %s (%d%%), polling again in %s", job.ID, current.State, current.PercentProgress, interval)
+		select {
+		case <-context.Done():
+			return current, correlationID, errors.WithStack(context.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Execute executes this RecordingJob
+//
+// The job must be READY.
+func (job RecordingJob) Execute(context context.Context) (correlationID string, err error) {
+	if err = job.checkInitialized(); err != nil {
+		return
+	}
+	return job.client.Put(
+		job.logger.ToContext(context),
+		job.GetURI(),
+		struct {
+			State RecordingJobState `json:"state"`
+		}{State: RecordingJobStateProcessing},
+		nil,
+	)
+}
+
+// Cancel cancels this RecordingJob
+func (job RecordingJob) Cancel(context context.Context) (correlationID string, err error) {
+	if err = job.checkInitialized(); err != nil {
+		return
+	}
+	return job.client.Delete(job.logger.ToContext(context), job.GetURI(), nil)
+}
+
+// FetchFailedRecordings fetches the recordings this RecordingJob could not process
+func (job RecordingJob) FetchFailedRecordings(context context.Context) (recordings []FailedRecording, correlationID string, err error) {
+	if err = job.checkInitialized(); err != nil {
+		return
+	}
+	entities, correlationID, err := job.client.FetchEntities(job.logger.ToContext(context), NewURI("/recording/jobs/%s/failedrecordings", job.ID))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	recordings = make([]FailedRecording, 0, len(entities))
+	for _, entity := range entities {
+		var recording FailedRecording
+		if err = json.Unmarshal(entity, &recording); err != nil {
+			return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+		}
+		recordings = append(recordings, recording)
+	}
+	return recordings, correlationID, nil
+}
+
+// checkInitialized checks if this Recording Job can send requests
+func (job RecordingJob) checkInitialized() error {
+	return checkInitialized(job.client, "Recording Job", job.ID)
+}
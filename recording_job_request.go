@@ -0,0 +1,138 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gildas/go-errors"
+)
+
+// RecordingJobAction describes what a RecordingJob does to the recordings it matches
+type RecordingJobAction string
+
+// Recording job actions
+const (
+	RecordingJobActionExport  RecordingJobAction = "EXPORT"
+	RecordingJobActionDelete  RecordingJobAction = "DELETE"
+	RecordingJobActionArchive RecordingJobAction = "ARCHIVE"
+)
+
+// RecordingJobRequest describes a request to create a RecordingJob
+//
+// The recordings are selected by an analytics conversation query over the given interval.
+//
+// Use NewRecordingJobRequest and the With methods to build it.
+//
+// See: https://developer.genesys.cloud/analyticsdatamanagement/recording/recording-apis#post-api-v2-recording-jobs
+type RecordingJobRequest struct {
+	Action                  RecordingJobAction
+	ActionDate              time.Time // when the action is performed, as soon as the job is executed if zero
+	Integration             Identifiable
+	IncludeScreenRecordings bool
+	ClearExport             bool
+	IntervalStart           time.Time
+	IntervalEnd             time.Time
+	ConversationFilters     []AnalyticsQueryFilter
+	SegmentFilters          []AnalyticsQueryFilter
+}
+
+// NewRecordingJobRequest creates a new RecordingJobRequest for the recordings of the conversations in the given interval
+func NewRecordingJobRequest(action RecordingJobAction, start, end time.Time) *RecordingJobRequest {
+	return &RecordingJobRequest{
+		Action:        action,
+		IntervalStart: start,
+		IntervalEnd:   end,
+	}
+}
+
+// WithActionDate sets when the action should be performed
+func (request *RecordingJobRequest) WithActionDate(actionDate time.Time) *RecordingJobRequest {
+	request.ActionDate = actionDate
+	return request
+}
+
+// WithIntegration sets the integration the recordings are exported to
+func (request *RecordingJobRequest) WithIntegration(integration Identifiable) *RecordingJobRequest {
+	request.Integration = integration
+	return request
+}
+
+// WithScreenRecordings tells the job to also process screen recordings
+func (request *RecordingJobRequest) WithScreenRecordings() *RecordingJobRequest {
+	request.IncludeScreenRecordings = true
+	return request
+}
+
+// WithConversationFilters adds filters on the conversations of the query
+func (request *RecordingJobRequest) WithConversationFilters(filters ...AnalyticsQueryFilter) *RecordingJobRequest {
+	request.ConversationFilters = append(request.ConversationFilters, filters...)
+	return request
+}
+
+// WithSegmentFilters adds filters on the conversation segments of the query
+func (request *RecordingJobRequest) WithSegmentFilters(filters ...AnalyticsQueryFilter) *RecordingJobRequest {
+	request.SegmentFilters = append(request.SegmentFilters, filters...)
+	return request
+}
+
+// Validate validates the recording job request
+func (request RecordingJobRequest) Validate() error {
+	var merr errors.MultiError
+	switch request.Action {
+	case RecordingJobActionExport:
+		if request.Integration == nil {
+			merr.Append(errors.ArgumentMissing.With("integrationId"))
+		}
+	case RecordingJobActionDelete, RecordingJobActionArchive:
+	case "":
+		merr.Append(errors.ArgumentMissing.With("action"))
+	default:
+		merr.Append(errors.ArgumentInvalid.With("action", request.Action, "EXPORT, DELETE, ARCHIVE"))
+	}
+	if request.IntervalStart.IsZero() || request.IntervalEnd.IsZero() {
+		merr.Append(errors.ArgumentMissing.With("interval"))
+	} else if !request.IntervalEnd.After(request.IntervalStart) {
+		merr.Append(errors.ArgumentInvalid.With("interval", request.interval(), "start before end"))
+	}
+	return merr.AsError()
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (request RecordingJobRequest) MarshalJSON() ([]byte, error) {
+	type conversationQuery struct {
+		Interval            string                 `json:"interval"`
+		ConversationFilters []AnalyticsQueryFilter `json:"conversationFilters,omitempty"`
+		SegmentFilters      []AnalyticsQueryFilter `json:"segmentFilters,omitempty"`
+	}
+	payload := struct {
+		Action                  RecordingJobAction `json:"action"`
+		ActionDate              *time.Time         `json:"actionDate,omitempty"`
+		IntegrationID           string             `json:"integrationId,omitempty"`
+		IncludeScreenRecordings bool               `json:"includeScreenRecordings,omitempty"`
+		ClearExport             bool               `json:"clearExport,omitempty"`
+		ConversationQuery       conversationQuery  `json:"conversationQuery"`
+	}{
+		Action:                  request.Action,
+		IntegrationID:           identifiableID(request.Integration),
+		IncludeScreenRecordings: request.IncludeScreenRecordings,
+		ClearExport:             request.ClearExport,
+		ConversationQuery: conversationQuery{
+			Interval:            request.interval(),
+			ConversationFilters: request.ConversationFilters,
+			SegmentFilters:      request.SegmentFilters,
+		},
+	}
+	if !request.ActionDate.IsZero() {
+		actionDate := request.ActionDate.UTC()
+		payload.ActionDate = &actionDate
+	}
+	data, err := json.Marshal(payload)
+	return data, errors.JSONMarshalError.Wrap(err)
+}
+
+// interval gets the ISO-8601 interval of the conversation query
+func (request RecordingJobRequest) interval() string {
+	return request.IntervalStart.UTC().Format(time.RFC3339) + "/" + request.IntervalEnd.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,370 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type RecordingSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestRecordingSuite(t *testing.T) {
+	suite.Run(t, new(RecordingSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *RecordingSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *RecordingSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *RecordingSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *RecordingSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *RecordingSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+type closableBuffer struct {
+	strings.Builder
+	Closed bool
+}
+
+func (buffer *closableBuffer) Close() error {
+	buffer.Closed = true
+	return nil
+}
+
+func (suite *RecordingSuite) TestCanDownloadArchivedRecording() {
+	conversationID := uuid.New()
+	recordingID := uuid.New()
+	recordingPath := fmt.Sprintf("/api/v2/conversations/%s/recordings/%s", conversationID, recordingID)
+	polls := 0
+	server := CreateRecordingTestServer(map[string]any{
+		"PUT " + recordingPath: struct{}{},
+		"GET /media/recording.mp3": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			suite.Assert().Empty(r.Header.Get("Authorization"), "The access token should not be sent to the media URI")
+			w.Header().Set("Content-Type", "audio/mpeg")
+			_, _ = w.Write([]byte("hello world"))
+		}),
+	})
+	defer server.Close()
+	server.Responses["GET "+recordingPath] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls == 1 {
+			core.RespondWithJSON(w, http.StatusAccepted, map[string]any{"id": recordingID, "fileState": "RESTORING", "estimatedTranscodeTimeMs": 10})
+			return
+		}
+		core.RespondWithJSON(w, http.StatusOK, map[string]any{
+			"id":                recordingID,
+			"fileState":         "RESTORED",
+			"outputSizeInBytes": 11,
+			"mediaUris":         map[string]any{"S": map[string]any{"mediaUri": server.URL + "/media/recording.mp3"}},
+		})
+	})
+	client := CreateTestClient(server.URL, suite.Logger)
+	recording := gcloudcx.New[gcloudcx.Recording](context.Background(), client, recordingID, suite.Logger)
+	recording.ConversationID = conversationID
+	recording.FileState = "ARCHIVED"
+
+	var media strings.Builder
+	_, err := recording.Download(context.Background(), gcloudcx.RecordingFormatMP3, &media)
+	suite.Require().NoErrorf(err, "Failed to download recording. %s", err)
+	suite.Assert().Equal("hello world", media.String())
+	suite.Assert().Equal(2, polls, "The recording should have been polled until it was restored")
+	suite.Require().Len(server.Requests, 4)
+	suite.Assert().Equal("PUT", server.Requests[0].Method)
+	suite.Assert().Equal("1", server.Requests[0].Query.Get("restoreDays"))
+	suite.Assert().Equal("MP3", server.Requests[1].Query.Get("formatId"))
+}
+
+func (suite *RecordingSuite) TestShouldFailDownloadWithWrongSize() {
+	conversationID := uuid.New()
+	recordingID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET /media/recording.wav": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("truncated"))
+		}),
+	})
+	defer server.Close()
+	server.Responses[fmt.Sprintf("GET /api/v2/conversations/%s/recordings/%s", conversationID, recordingID)] = map[string]any{
+		"id":                recordingID,
+		"fileState":         "AVAILABLE",
+		"outputSizeInBytes": 1024,
+		"mediaUris":         map[string]any{"S": map[string]any{"mediaUri": server.URL + "/media/recording.wav"}},
+	}
+	client := CreateTestClient(server.URL, suite.Logger)
+	recording := gcloudcx.New[gcloudcx.Recording](context.Background(), client, recordingID, suite.Logger)
+	recording.ConversationID = conversationID
+
+	var media strings.Builder
+	_, err := recording.Download(context.Background(), gcloudcx.RecordingFormatWAV, &media)
+	suite.Assert().Error(err, "A truncated download should fail")
+
+	_, err = recording.Download(context.Background(), "FLAC", &media)
+	suite.Assert().Error(err, "An unknown format should fail")
+}
+
+func (suite *RecordingSuite) TestCanDownloadConversationRecordings() {
+	conversationID := uuid.New()
+	audioID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET /media/recording.ogg": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("opus"))
+		}),
+	})
+	defer server.Close()
+	server.Responses[fmt.Sprintf("GET /api/v2/conversations/%s/recordings", conversationID)] = []map[string]any{
+		{"id": audioID, "conversationId": conversationID, "media": "audio", "fileState": "AVAILABLE"},
+		{"id": uuid.New(), "conversationId": conversationID, "media": "chat", "fileState": "AVAILABLE"},
+	}
+	server.Responses[fmt.Sprintf("GET /api/v2/conversations/%s/recordings/%s", conversationID, audioID)] = map[string]any{
+		"id":        audioID,
+		"fileState": "AVAILABLE",
+		"mediaUris": map[string]any{"S": map[string]any{"mediaUri": server.URL + "/media/recording.ogg"}},
+	}
+	client := CreateTestClient(server.URL, suite.Logger)
+	conversation := gcloudcx.New[gcloudcx.Conversation](context.Background(), client, conversationID, suite.Logger)
+
+	buffers := map[uuid.UUID]*closableBuffer{}
+	_, err := conversation.DownloadRecordings(context.Background(), gcloudcx.RecordingFormatOGGOpus, func(recording gcloudcx.Recording) (io.WriteCloser, error) {
+		buffers[recording.ID] = &closableBuffer{}
+		return buffers[recording.ID], nil
+	})
+	suite.Require().NoErrorf(err, "Failed to download recordings. %s", err)
+	suite.Require().Len(buffers, 1, "Only the audio recording should be downloaded")
+	suite.Assert().Equal("opus", buffers[audioID].String())
+	suite.Assert().True(buffers[audioID].Closed, "The writer should be closed")
+}
+
+func (suite *RecordingSuite) TestCanCreateRecordingJob() {
+	jobID := uuid.New()
+	integrationID := uuid.New()
+	queueID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/recording/jobs": map[string]any{"id": jobID, "state": "PENDING"},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	request := gcloudcx.NewRecordingJobRequest(gcloudcx.RecordingJobActionExport, start, start.AddDate(0, 1, 0)).
+		WithIntegration(gcloudcx.Integration{ID: integrationID}).
+		WithSegmentFilters(gcloudcx.NewAnalyticsQueryFilter("queueId", queueID.String()))
+	job, _, err := client.CreateRecordingJob(context.Background(), request)
+	suite.Require().NoErrorf(err, "Failed to create recording job. %s", err)
+	suite.Assert().Equal(jobID, job.GetID())
+	suite.Assert().Equal(gcloudcx.RecordingJobStatePending, job.State)
+	suite.Assert().JSONEq(fmt.Sprintf(`{
+		"action": "EXPORT",
+		"integrationId": "%s",
+		"conversationQuery": {
+			"interval": "2026-01-01T00:00:00Z/2026-02-01T00:00:00Z",
+			"segmentFilters": [{"type": "and", "predicates": [{"type": "dimension", "dimension": "queueId", "operator": "matches", "value": "%s"}]}]
+		}
+	}`, integrationID, queueID), string(server.LastRequest().Body))
+
+	suite.Assert().Error(gcloudcx.NewRecordingJobRequest(gcloudcx.RecordingJobActionExport, start, start.Add(time.Hour)).Validate(), "An export without integration should fail")
+	suite.Assert().Error(gcloudcx.NewRecordingJobRequest(gcloudcx.RecordingJobActionDelete, start, start.Add(-time.Hour)).Validate(), "An inverted interval should fail")
+	suite.Assert().Error(gcloudcx.NewRecordingJobRequest("PURGE", start, start.Add(time.Hour)).Validate(), "An unknown action should fail")
+	suite.Assert().NoError(gcloudcx.NewRecordingJobRequest(gcloudcx.RecordingJobActionArchive, start, start.Add(time.Hour)).Validate())
+}
+
+func (suite *RecordingSuite) TestCanRunRecordingJob() {
+	jobID := uuid.New()
+	jobPath := fmt.Sprintf("/api/v2/recording/jobs/%s", jobID)
+	states := []map[string]any{
+		{"id": jobID, "state": "PENDING"},
+		{"id": jobID, "state": "READY", "totalConversations": 2, "totalRecordings": 3},
+		{"id": jobID, "state": "PROCESSING", "totalRecordings": 3, "totalProcessedRecordings": 1, "percentProgress": 33},
+		{"id": jobID, "state": "FULFILLED", "totalRecordings": 3, "totalProcessedRecordings": 2, "totalFailedRecordings": 1, "percentProgress": 100},
+	}
+	polls := 0
+	failedConversationID := uuid.New()
+	failedRecordingID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET " + jobPath: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			core.RespondWithJSON(w, http.StatusOK, states[polls])
+			polls++
+		}),
+		"PUT " + jobPath:    struct{}{},
+		"DELETE " + jobPath: struct{}{},
+		"GET " + jobPath + "/failedrecordings": map[string]any{
+			"entities":  []map[string]any{{"conversationId": failedConversationID, "recordingId": failedRecordingID}},
+			"pageCount": 1,
+		},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	job := gcloudcx.New[gcloudcx.RecordingJob](context.Background(), client, jobID, suite.Logger)
+
+	progress := []gcloudcx.RecordingJobProgress{}
+	ready, _, err := job.Wait(context.Background(), 10*time.Millisecond, func(p gcloudcx.RecordingJobProgress) { progress = append(progress, p) })
+	suite.Require().NoErrorf(err, "Failed to wait for the recording job. %s", err)
+	suite.Assert().Equal(gcloudcx.RecordingJobStateReady, ready.State)
+	suite.Assert().Equal(3, ready.TotalRecordings)
+	suite.Assert().Len(progress, 2)
+
+	_, err = ready.Execute(context.Background())
+	suite.Require().NoErrorf(err, "Failed to execute the recording job. %s", err)
+	suite.Assert().JSONEq(`{"state": "PROCESSING"}`, string(server.LastRequest().Body))
+
+	done, _, err := ready.Wait(context.Background(), 10*time.Millisecond, func(p gcloudcx.RecordingJobProgress) { progress = append(progress, p) })
+	suite.Require().NoErrorf(err, "Failed to wait for the recording job. %s", err)
+	suite.Assert().True(done.State.IsFinal())
+	suite.Require().Len(progress, 4)
+	suite.Assert().Equal(gcloudcx.RecordingJobProgress{State: gcloudcx.RecordingJobStateFulfilled, TotalRecordings: 3, Processed: 2, Failed: 1, Percent: 100}, progress[3])
+
+	failed, _, err := done.FetchFailedRecordings(context.Background())
+	suite.Require().NoErrorf(err, "Failed to fetch failed recordings. %s", err)
+	suite.Assert().Equal([]gcloudcx.FailedRecording{{ConversationID: failedConversationID, RecordingID: failedRecordingID}}, failed)
+
+	_, err = done.Cancel(context.Background())
+	suite.Require().NoErrorf(err, "Failed to cancel the recording job. %s", err)
+	suite.Assert().Equal("DELETE", server.LastRequest().Method)
+}
+
+func (suite *RecordingSuite) TestCanManageAnnotations() {
+	conversationID := uuid.New()
+	recordingID := uuid.New()
+	annotationID := uuid.New()
+	annotationsPath := fmt.Sprintf("/api/v2/conversations/%s/recordings/%s/annotations", conversationID, recordingID)
+	server := CreateRecordingTestServer(map[string]any{
+		"POST " + annotationsPath:                                 map[string]any{"id": annotationID, "name": "Greeting", "type": "bookmark", "location": 1500, "durationMs": 3000},
+		"PUT " + annotationsPath + "/" + annotationID.String():    map[string]any{"id": annotationID, "name": "Greeting", "type": "bookmark", "location": 2000, "durationMs": 3000},
+		"DELETE " + annotationsPath + "/" + annotationID.String(): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	recording := gcloudcx.New[gcloudcx.Recording](context.Background(), client, recordingID, suite.Logger)
+	recording.ConversationID = conversationID
+
+	annotation, _, err := recording.CreateAnnotation(context.Background(), gcloudcx.NewRecordingBookmark("Greeting", 1500*time.Millisecond, 3*time.Second, "Agent greets the customer"))
+	suite.Require().NoErrorf(err, "Failed to create annotation. %s", err)
+	suite.Assert().JSONEq(`{"name": "Greeting", "type": "bookmark", "description": "Agent greets the customer", "location": 1500, "durationMs": 3000}`, string(server.LastRequest().Body))
+	suite.Assert().Equal(annotationID, annotation.GetID())
+	suite.Assert().Equal(1500*time.Millisecond, annotation.Location)
+
+	annotation.Location = 2 * time.Second
+	annotation, _, err = recording.UpdateAnnotation(context.Background(), *annotation)
+	suite.Require().NoErrorf(err, "Failed to update annotation. %s", err)
+	suite.Assert().Equal(2*time.Second, annotation.Location)
+
+	_, err = recording.DeleteAnnotation(context.Background(), annotation)
+	suite.Require().NoErrorf(err, "Failed to delete annotation. %s", err)
+	suite.Assert().Equal("DELETE", server.LastRequest().Method)
+
+	_, _, err = recording.CreateAnnotation(context.Background(), gcloudcx.RecordingAnnotation{Type: "bookmark"})
+	suite.Assert().Error(err, "An annotation without a name should fail")
+}
+
+func (suite *RecordingSuite) TestShouldKeepAnnotationsWhenMarshalingRecording() {
+	userID := uuid.New()
+	recording := gcloudcx.Recording{
+		ID: uuid.New(),
+		Annotations: []gcloudcx.RecordingAnnotation{{
+			ID:      uuid.New(),
+			Name:    "Hold",
+			Type:    gcloudcx.RecordingAnnotationTypeHold,
+			Reason:  "agent",
+			User:    &gcloudcx.User{ID: userID},
+			SelfURI: "/api/v2/annotations/1",
+		}},
+	}
+	data, err := json.Marshal(recording)
+	suite.Require().NoErrorf(err, "Failed to marshal recording. %s", err)
+
+	var decoded gcloudcx.Recording
+	suite.Require().NoErrorf(json.Unmarshal(data, &decoded), "Failed to unmarshal recording")
+	suite.Require().Len(decoded.Annotations, 1)
+	suite.Assert().Equal("agent", decoded.Annotations[0].Reason)
+	suite.Require().NotNil(decoded.Annotations[0].User)
+	suite.Assert().Equal(userID, decoded.Annotations[0].User.ID)
+	suite.Assert().Equal("/api/v2/annotations/1", decoded.Annotations[0].SelfURI)
+}
+
+func (suite *RecordingSuite) TestCanComputeWaveformStatistics() {
+	recording := gcloudcx.Recording{
+		OutputDuration: 10 * time.Second,
+		MediaURIs: map[string]*gcloudcx.RecordingMediaURI{
+			"S": {Data: []float64{0.5, 0.6, 0, 0, 0, 0.4, 0, 0.7, 0, 0.05}},
+		},
+		Annotations: []gcloudcx.RecordingAnnotation{
+			{Type: "hold", Location: 8 * time.Second, Duration: 2 * time.Second},
+			{Type: "bookmark", Name: "Greeting", Location: 0, Duration: time.Second},
+		},
+	}
+	segments := recording.Segments(0.1, 2*time.Second)
+	suite.Assert().Equal([]gcloudcx.RecordingSegment{
+		{Type: gcloudcx.RecordingSegmentTalk, Start: 0, Duration: 2 * time.Second},
+		{Type: gcloudcx.RecordingSegmentSilence, Start: 2 * time.Second, Duration: 3 * time.Second},
+		{Type: gcloudcx.RecordingSegmentTalk, Start: 5 * time.Second, Duration: 3 * time.Second},
+		{Type: gcloudcx.RecordingSegmentSilence, Start: 8 * time.Second, Duration: 2 * time.Second},
+	}, segments)
+
+	statistics := recording.Statistics(0.1, 2*time.Second)
+	suite.Assert().Equal(10*time.Second, statistics.Duration)
+	suite.Assert().Equal(5*time.Second, statistics.Talk)
+	suite.Assert().Equal(3*time.Second, statistics.Silence, "Silence on hold should not be counted")
+	suite.Assert().Equal(2*time.Second, statistics.Hold)
+	suite.Assert().InDelta(0.3, statistics.SilenceRatio, 0.0001)
+	suite.Assert().InDelta(0.2, statistics.HoldRatio, 0.0001)
+
+	suite.Assert().Empty(gcloudcx.WaveformSegments(nil, time.Minute, 0.1, time.Second))
+}
@@ -0,0 +1,156 @@
+package gcloudcx
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecordingSegmentType describes the type of a RecordingSegment
+type RecordingSegmentType string
+
+// Recording segment types
+const (
+	RecordingSegmentTalk    RecordingSegmentType = "talk"
+	RecordingSegmentSilence RecordingSegmentType = "silence"
+)
+
+// RecordingSegment describes a part of a Recording where the participants talk or are silent
+type RecordingSegment struct {
+	Type     RecordingSegmentType `json:"type"`
+	Start    time.Duration        `json:"start"` // Offset from start of recording
+	Duration time.Duration        `json:"duration"`
+}
+
+// RecordingStatistics describes how the time of a Recording is spent
+//
+// Silence and Talk do not include the time spent on hold or paused.
+type RecordingStatistics struct {
+	Duration     time.Duration `json:"duration"`
+	Talk         time.Duration `json:"talk"`
+	Silence      time.Duration `json:"silence"`
+	Hold         time.Duration `json:"hold"`
+	Pause        time.Duration `json:"pause"`
+	SilenceRatio float64       `json:"silenceRatio"`
+	HoldRatio    float64       `json:"holdRatio"`
+}
+
+// End gets the offset of the end of this segment from the start of the recording
+func (segment RecordingSegment) End() time.Duration {
+	return segment.Start + segment.Duration
+}
+
+// WaveformSegments splits waveform samples in talk and silence segments
+//
+// The samples are evenly spread over the given duration, samples with an amplitude lower than or equal to threshold are silent.
+// Silences shorter than minimumSilence are considered as talk.
+func WaveformSegments(samples []float64, duration time.Duration, threshold float64, minimumSilence time.Duration) []RecordingSegment {
+	segments := []RecordingSegment{}
+	if len(samples) == 0 || duration <= 0 {
+		return segments
+	}
+	offset := func(index int) time.Duration {
+		return time.Duration(int64(duration) * int64(index) / int64(len(samples)))
+	}
+	start := 0
+	for index := 1; index <= len(samples); index++ {
+		if index < len(samples) && isSilentSample(samples[index], threshold) == isSilentSample(samples[start], threshold) {
+			continue
+		}
+		segment := RecordingSegment{Type: RecordingSegmentTalk, Start: offset(start), Duration: offset(index) - offset(start)}
+		if isSilentSample(samples[start], threshold) && segment.Duration >= minimumSilence {
+			segment.Type = RecordingSegmentSilence
+		}
+		if last := len(segments) - 1; last >= 0 && segments[last].Type == segment.Type {
+			segments[last].Duration += segment.Duration
+		} else {
+			segments = append(segments, segment)
+		}
+		start = index
+	}
+	return segments
+}
+
+// Segments gets the talk and silence segments of this Recording from its waveform
+//
+// See WaveformSegments for the meaning of threshold and minimumSilence.
+func (recording Recording) Segments(threshold float64, minimumSilence time.Duration) []RecordingSegment {
+	return WaveformSegments(recording.waveform(), recording.duration(), threshold, minimumSilence)
+}
+
+// Statistics computes the talk, silence, hold, and pause durations of this Recording
+//
+// The hold and pause durations come from the annotations of the Recording, the talk and silence durations from its waveform.
+func (recording Recording) Statistics(threshold float64, minimumSilence time.Duration) RecordingStatistics {
+	statistics := RecordingStatistics{Duration: recording.duration()}
+	excluded := []RecordingSegment{}
+	for _, annotation := range recording.Annotations {
+		switch strings.ToLower(annotation.Type) {
+		case RecordingAnnotationTypeHold:
+			statistics.Hold += annotation.Duration
+		case RecordingAnnotationTypePause:
+			statistics.Pause += annotation.Duration
+		default:
+			continue
+		}
+		excluded = append(excluded, RecordingSegment{Start: annotation.Location, Duration: annotation.Duration})
+	}
+	for _, segment := range recording.Segments(threshold, minimumSilence) {
+		remaining := segment.Duration - overlapDuration(segment, excluded)
+		if segment.Type == RecordingSegmentSilence {
+			statistics.Silence += remaining
+		} else {
+			statistics.Talk += remaining
+		}
+	}
+	if statistics.Duration > 0 {
+		statistics.SilenceRatio = float64(statistics.Silence) / float64(statistics.Duration)
+		statistics.HoldRatio = float64(statistics.Hold) / float64(statistics.Duration)
+	}
+	return statistics
+}
+
+// waveform gets the waveform samples of the first media of this Recording
+func (recording Recording) waveform() []float64 {
+	keys := make([]string, 0, len(recording.MediaURIs))
+	for key, mediaURI := range recording.MediaURIs {
+		if mediaURI != nil && len(mediaURI.Data) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return recording.MediaURIs[keys[0]].Data
+}
+
+// duration gets the duration of this Recording
+func (recording Recording) duration() time.Duration {
+	if recording.OutputDuration > 0 {
+		return recording.OutputDuration
+	}
+	if !recording.StartTime.IsZero() && recording.EndTime.After(recording.StartTime) {
+		return recording.EndTime.Sub(recording.StartTime)
+	}
+	return 0
+}
+
+func isSilentSample(sample, threshold float64) bool {
+	return math.Abs(sample) <= threshold
+}
+
+// overlapDuration gets how much of the segment is covered by the other segments
+//
+// The other segments are not expected to overlap each other.
+func overlapDuration(segment RecordingSegment, others []RecordingSegment) (overlap time.Duration) {
+	for _, other := range others {
+		start := max(segment.Start, other.Start)
+		end := min(segment.End(), other.End())
+		if end > start {
+			overlap += end - start
+		}
+	}
+	return
+}
@@ -2,9 +2,11 @@ package gcloudcx_test
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -191,3 +193,58 @@ func CreateTestClient(serverURL string, log *logger.Logger) *gcloudcx.Client {
 	}
 	return client
 }
+
+// RecordedRequest describes a request received by a recording test server
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// RecordingTestServer is a test server that records the requests it receives
+//
+// Responses are keyed by "METHOD /api/v2/path", their value is sent as JSON with a 200 status,
+// or called if it is a http.HandlerFunc. Unknown requests get a 404.
+type RecordingTestServer struct {
+	*httptest.Server
+	Requests  []RecordedRequest
+	Responses map[string]any
+	mutex     sync.Mutex
+}
+
+func CreateRecordingTestServer(responses map[string]any) *RecordingTestServer {
+	server := &RecordingTestServer{Responses: responses}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		server.mutex.Lock()
+		server.Requests = append(server.Requests, RecordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+		response, found := server.Responses[r.Method+" "+r.URL.Path]
+		server.mutex.Unlock()
+		w.Header().Add("Inin-Correlation-Id", "12345")
+		if !found {
+			core.RespondWithJSON(w, http.StatusNotFound, struct {
+				Status  int    `json:"status"`
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}{http.StatusNotFound, "not.found", "The requested resource was not found"})
+			return
+		}
+		if handler, ok := response.(http.HandlerFunc); ok {
+			handler(w, r)
+			return
+		}
+		core.RespondWithJSON(w, http.StatusOK, response)
+	}))
+	return server
+}
+
+// LastRequest gets the last request received by the server
+func (server *RecordingTestServer) LastRequest() RecordedRequest {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if len(server.Requests) == 0 {
+		return RecordedRequest{}
+	}
+	return server.Requests[len(server.Requests)-1]
+}
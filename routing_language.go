@@ -0,0 +1,84 @@
+package gcloudcx
+
+import (
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// RoutingLanguage describes a Routing Language, that agents must speak to get some interactions
+//
+// RoutingLanguages are fetched by name with FetchBy:
+//
+//	language, correlationID, err := gcloudcx.FetchBy(context, client, func(language gcloudcx.RoutingLanguage) bool { return language.Name == "French" })
+//
+// See: https://developer.genesys.cloud/routing/routing/#get-api-v2-routing-languages
+type RoutingLanguage struct {
+	ID           uuid.UUID      `json:"id"`
+	Name         string         `json:"name"`
+	State        string         `json:"state,omitempty"` // active, inactive, deleted
+	DateModified time.Time      `json:"dateModified,omitempty"`
+	Version      string         `json:"version,omitempty"`
+	SelfURI      URI            `json:"selfUri,omitempty"`
+	client       *Client        `json:"-"`
+	logger       *logger.Logger `json:"-"`
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (language *RoutingLanguage) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			language.ID = parameter
+		case *Client:
+			language.client = parameter
+		case *logger.Logger:
+			language.logger = parameter.Child("routinglanguage", "routinglanguage", "id", language.ID)
+		}
+	}
+	if language.logger == nil {
+		language.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (language RoutingLanguage) GetID() uuid.UUID {
+	return language.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (language RoutingLanguage) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/routing/languages/%s", ids[0])
+	}
+	if language.ID != uuid.Nil {
+		return NewURI("/api/v2/routing/languages/%s", language.ID)
+	}
+	return URI("/api/v2/routing/languages/")
+}
+
+// GetName gets the name of this
+//
+// implements Named
+func (language RoutingLanguage) GetName() string {
+	return language.Name
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (language RoutingLanguage) String() string {
+	if len(language.Name) > 0 {
+		return language.Name
+	}
+	return language.ID.String()
+}
@@ -0,0 +1,84 @@
+package gcloudcx
+
+import (
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// RoutingSkill describes a Routing Skill, that agents must have to get some interactions
+//
+// RoutingSkills are fetched by name with FetchBy:
+//
+//	skill, correlationID, err := gcloudcx.FetchBy(context, client, func(skill gcloudcx.RoutingSkill) bool { return skill.Name == "French" })
+//
+// See: https://developer.genesys.cloud/routing/routing/#get-api-v2-routing-skills
+type RoutingSkill struct {
+	ID           uuid.UUID      `json:"id"`
+	Name         string         `json:"name"`
+	State        string         `json:"state,omitempty"` // active, inactive, deleted
+	DateModified time.Time      `json:"dateModified,omitempty"`
+	Version      string         `json:"version,omitempty"`
+	SelfURI      URI            `json:"selfUri,omitempty"`
+	client       *Client        `json:"-"`
+	logger       *logger.Logger `json:"-"`
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (skill *RoutingSkill) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			skill.ID = parameter
+		case *Client:
+			skill.client = parameter
+		case *logger.Logger:
+			skill.logger = parameter.Child("routingskill", "routingskill", "id", skill.ID)
+		}
+	}
+	if skill.logger == nil {
+		skill.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (skill RoutingSkill) GetID() uuid.UUID {
+	return skill.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (skill RoutingSkill) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/routing/skills/%s", ids[0])
+	}
+	if skill.ID != uuid.Nil {
+		return NewURI("/api/v2/routing/skills/%s", skill.ID)
+	}
+	return URI("/api/v2/routing/skills/")
+}
+
+// GetName gets the name of this
+//
+// implements Named
+func (skill RoutingSkill) GetName() string {
+	return skill.Name
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (skill RoutingSkill) String() string {
+	if len(skill.Name) > 0 {
+		return skill.Name
+	}
+	return skill.ID.String()
+}
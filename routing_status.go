@@ -10,3 +10,12 @@ type RoutingStatus struct {
 	Status    string    `json:"status"` // OFF_QUEUE, IDLE, INTERACTING, NOT_RESPONDING, COMMUNICATING
 	StartTime time.Time `json:"startTime"`
 }
+
+// Routing statuses
+const (
+	RoutingStatusOffQueue      = "OFF_QUEUE"
+	RoutingStatusIdle          = "IDLE"
+	RoutingStatusInteracting   = "INTERACTING"
+	RoutingStatusNotResponding = "NOT_RESPONDING"
+	RoutingStatusCommunicating = "COMMUNICATING"
+)
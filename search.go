@@ -0,0 +1,88 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+
+	"github.com/gildas/go-errors"
+)
+
+// Search searches resources with the given request and streams the results
+//
+// The results are fetched page by page as they are consumed, stopping the loop stops fetching pages.
+// If a page cannot be fetched, the error is yielded and the search stops.
+//
+//	for user, err := range gcloudcx.Search[gcloudcx.User](context, client, gcloudcx.NewURI("/users/search"), request) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    log.Infof("Found %s", user)
+//	}
+func Search[T any, PT interface {
+	Initializable
+	*T
+}](context context.Context, client *Client, uri URI, request *SearchRequest) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		if request == nil {
+			yield(nil, errors.ArgumentMissing.With("request"))
+			return
+		}
+		if err := request.Validate(); err != nil {
+			yield(nil, err)
+			return
+		}
+		log := client.GetLogger(context).Child(nil, "search", "uri", uri)
+		page := *request
+		for page.pageNumber = 1; ; page.pageNumber++ {
+			results := struct {
+				Results   []json.RawMessage `json:"results"`
+				Total     int64             `json:"total"`
+				PageCount int               `json:"pageCount"`
+			}{}
+			correlationID, err := client.Post(context, uri, page, &results)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			log.Record("genesys-correlation", correlationID).Debugf("Page %d/%d: %d results (total: %d)", page.pageNumber, results.PageCount, len(results.Results), results.Total)
+			for _, result := range results.Results {
+				var object T
+				if err := json.Unmarshal(result, &object); err != nil {
+					if !yield(nil, errors.JSONUnmarshalError.Wrap(err)) {
+						return
+					}
+					continue
+				}
+				PT(&object).Initialize(client, log)
+				if !yield(&object, nil) {
+					return
+				}
+			}
+			if len(results.Results) == 0 || page.pageNumber >= results.PageCount {
+				return
+			}
+		}
+	}
+}
+
+// SearchUsers searches users
+//
+// See: https://developer.genesys.cloud/useragentman/users/#post-api-v2-users-search
+func (client *Client) SearchUsers(context context.Context, request *SearchRequest) iter.Seq2[*User, error] {
+	return Search[User](context, client, NewURI("/users/search"), request)
+}
+
+// SearchGroups searches groups
+//
+// See: https://developer.genesys.cloud/useragentman/groups/#post-api-v2-groups-search
+func (client *Client) SearchGroups(context context.Context, request *SearchRequest) iter.Seq2[*Group, error] {
+	return Search[Group](context, client, NewURI("/groups/search"), request)
+}
+
+// SearchConversations searches conversations by the attributes of their participants
+//
+// See: https://developer.genesys.cloud/routing/conversations/conversations-apis#post-api-v2-conversations-participants-attributes-search
+func (client *Client) SearchConversations(context context.Context, request *SearchRequest) iter.Seq2[*Conversation, error] {
+	return Search[Conversation](context, client, NewURI("/conversations/participants/attributes/search"), request)
+}
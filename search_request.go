@@ -0,0 +1,202 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+)
+
+// SearchRequest describes a search in Genesys Cloud (users, groups, conversations)
+//
+// Use NewSearchRequest and the With methods to build it, the criteria are combined with AND.
+//
+// See: https://developer.genesys.cloud/useragentman/users/#post-api-v2-users-search
+type SearchRequest struct {
+	Query      []SearchCriteria
+	Sort       []SearchSort
+	Expand     []string
+	PageSize   int
+	pageNumber int // set by Search when paging
+}
+
+// SearchCriteria describes a criteria of a SearchRequest
+//
+// Use SearchExact, SearchContains, SearchRange, SearchQueryString, SearchAnd, SearchOr, or SearchNot to create it.
+type SearchCriteria struct {
+	Type       SearchType       `json:"type,omitempty"`
+	Fields     []string         `json:"fields,omitempty"`
+	Value      string           `json:"value,omitempty"`
+	Values     []string         `json:"values,omitempty"`
+	StartValue string           `json:"startValue,omitempty"`
+	EndValue   string           `json:"endValue,omitempty"`
+	Operator   string           `json:"operator,omitempty"` // AND, OR, NOT
+	Group      []SearchCriteria `json:"group,omitempty"`
+}
+
+// SearchSort describes how the results of a SearchRequest are sorted
+type SearchSort struct {
+	SortBy    string `json:"sortBy"`
+	SortOrder string `json:"sortOrder"` // ASC, DESC
+}
+
+// SearchType describes how a SearchCriteria matches
+type SearchType string
+
+// Search types
+const (
+	SearchTypeExact       SearchType = "EXACT"
+	SearchTypeContains    SearchType = "CONTAINS"
+	SearchTypeStartsWith  SearchType = "STARTS_WITH"
+	SearchTypeRange       SearchType = "RANGE"
+	SearchTypeQueryString SearchType = "QUERY_STRING"
+)
+
+// NewSearchRequest creates a new SearchRequest with the given criteria
+func NewSearchRequest(criteria ...SearchCriteria) *SearchRequest {
+	return &SearchRequest{Query: criteria}
+}
+
+// Where adds criteria to the request
+func (request *SearchRequest) Where(criteria ...SearchCriteria) *SearchRequest {
+	request.Query = append(request.Query, criteria...)
+	return request
+}
+
+// OrderBy sorts the results by the given field, several fields can be given with several calls
+func (request *SearchRequest) OrderBy(field string, ascending bool) *SearchRequest {
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+	request.Sort = append(request.Sort, SearchSort{SortBy: field, SortOrder: order})
+	return request
+}
+
+// WithExpand expands the given properties in the results (e.g. "routingStatus", "presence")
+func (request *SearchRequest) WithExpand(properties ...string) *SearchRequest {
+	request.Expand = append(request.Expand, properties...)
+	return request
+}
+
+// WithPageSize sets how many results are fetched per request (1 to 100)
+func (request *SearchRequest) WithPageSize(size int) *SearchRequest {
+	request.PageSize = size
+	return request
+}
+
+// SearchExact matches the resources whose fields equal the value
+func SearchExact(value string, fields ...string) SearchCriteria {
+	return SearchCriteria{Type: SearchTypeExact, Value: value, Fields: fields}
+}
+
+// SearchContains matches the resources whose fields contain the value
+func SearchContains(value string, fields ...string) SearchCriteria {
+	return SearchCriteria{Type: SearchTypeContains, Value: value, Fields: fields}
+}
+
+// SearchStartsWith matches the resources whose fields start with the value
+func SearchStartsWith(value string, fields ...string) SearchCriteria {
+	return SearchCriteria{Type: SearchTypeStartsWith, Value: value, Fields: fields}
+}
+
+// SearchRange matches the resources whose fields are between start and end
+//
+// Either start or end can be empty for an open range. Dates must be ISO-8601 strings.
+func SearchRange(start, end string, fields ...string) SearchCriteria {
+	return SearchCriteria{Type: SearchTypeRange, StartValue: start, EndValue: end, Fields: fields}
+}
+
+// SearchQueryString matches the resources with a query string (e.g. "john AND doe")
+func SearchQueryString(query string, fields ...string) SearchCriteria {
+	return SearchCriteria{Type: SearchTypeQueryString, Value: query, Fields: fields}
+}
+
+// SearchAnd matches the resources that match all the given criteria
+func SearchAnd(criteria ...SearchCriteria) SearchCriteria {
+	return SearchCriteria{Operator: "AND", Group: criteria}
+}
+
+// SearchOr matches the resources that match at least one of the given criteria
+func SearchOr(criteria ...SearchCriteria) SearchCriteria {
+	return SearchCriteria{Operator: "OR", Group: criteria}
+}
+
+// SearchNot matches the resources that do not match the given criteria
+func SearchNot(criteria ...SearchCriteria) SearchCriteria {
+	return SearchCriteria{Operator: "NOT", Group: criteria}
+}
+
+// Validate validates the search request
+func (request SearchRequest) Validate() error {
+	var merr errors.MultiError
+	if len(request.Query) == 0 {
+		merr.Append(errors.ArgumentMissing.With("query"))
+	}
+	for _, criteria := range request.Query {
+		merr.Append(criteria.Validate())
+	}
+	if request.PageSize < 0 || request.PageSize > 100 {
+		merr.Append(errors.ArgumentInvalid.With("pageSize", request.PageSize, "1 to 100"))
+	}
+	return merr.AsError()
+}
+
+// Validate validates the search criteria
+func (criteria SearchCriteria) Validate() error {
+	var merr errors.MultiError
+	if len(criteria.Group) > 0 {
+		for _, child := range criteria.Group {
+			merr.Append(child.Validate())
+		}
+		return merr.AsError()
+	}
+	switch criteria.Type {
+	case SearchTypeExact, SearchTypeContains, SearchTypeStartsWith:
+		if len(criteria.Fields) == 0 {
+			merr.Append(errors.ArgumentMissing.With("fields"))
+		}
+		if len(criteria.Value) == 0 && len(criteria.Values) == 0 {
+			merr.Append(errors.ArgumentMissing.With("value"))
+		}
+	case SearchTypeRange:
+		if len(criteria.Fields) == 0 {
+			merr.Append(errors.ArgumentMissing.With("fields"))
+		}
+		if len(criteria.StartValue) == 0 && len(criteria.EndValue) == 0 {
+			merr.Append(errors.ArgumentMissing.With("startValue"))
+		}
+	case SearchTypeQueryString:
+		if len(criteria.Value) == 0 {
+			merr.Append(errors.ArgumentMissing.With("value"))
+		}
+	case "":
+		merr.Append(errors.ArgumentMissing.With("type"))
+	default:
+		merr.Append(errors.ArgumentInvalid.With("type", criteria.Type))
+	}
+	return merr.AsError()
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (request SearchRequest) MarshalJSON() ([]byte, error) {
+	pageSize := request.PageSize
+	if pageSize == 0 {
+		pageSize = 25
+	}
+	data, err := json.Marshal(struct {
+		Query      []SearchCriteria `json:"query"`
+		Sort       []SearchSort     `json:"sort,omitempty"`
+		Expand     []string         `json:"expand,omitempty"`
+		PageSize   int              `json:"pageSize"`
+		PageNumber int              `json:"pageNumber,omitempty"`
+	}{
+		Query:      request.Query,
+		Sort:       request.Sort,
+		Expand:     request.Expand,
+		PageSize:   pageSize,
+		PageNumber: request.pageNumber,
+	})
+	return data, errors.JSONMarshalError.Wrap(err)
+}
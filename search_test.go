@@ -0,0 +1,165 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type SearchSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, new(SearchSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *SearchSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *SearchSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *SearchSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *SearchSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *SearchSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *SearchSuite) TestCanSearchUsers() {
+	userIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	server := CreateRecordingTestServer(map[string]any{})
+	defer server.Close()
+	server.Responses["POST /api/v2/users/search"] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			PageNumber int `json:"pageNumber"`
+		}
+		_ = json.Unmarshal(server.LastRequest().Body, &request)
+		results := []map[string]any{{"id": userIDs[0], "name": "John Doe"}, {"id": userIDs[1], "name": "Jane Doe"}}
+		if request.PageNumber == 2 {
+			results = []map[string]any{{"id": userIDs[2], "name": "Jim Doe"}}
+		}
+		core.RespondWithJSON(w, http.StatusOK, map[string]any{"total": 3, "pageCount": 2, "pageNumber": request.PageNumber, "results": results})
+	})
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	request := gcloudcx.NewSearchRequest(
+		gcloudcx.SearchContains("doe", "name"),
+		gcloudcx.SearchOr(gcloudcx.SearchExact("Sales", "department"), gcloudcx.SearchExact("Support", "department")),
+	).OrderBy("name", true).WithExpand("routingStatus").WithPageSize(2)
+	users := []*gcloudcx.User{}
+	for user, err := range client.SearchUsers(context.Background(), request) {
+		suite.Require().NoErrorf(err, "Failed to search users. %s", err)
+		users = append(users, user)
+	}
+	suite.Require().Len(users, 3)
+	suite.Assert().Equal(userIDs[2], users[2].GetID())
+	suite.Assert().Equal("Jim Doe", users[2].String())
+	suite.Require().Len(server.Requests, 2)
+	suite.Assert().JSONEq(`{
+		"query": [
+			{"type": "CONTAINS", "fields": ["name"], "value": "doe"},
+			{"operator": "OR", "group": [
+				{"type": "EXACT", "fields": ["department"], "value": "Sales"},
+				{"type": "EXACT", "fields": ["department"], "value": "Support"}
+			]}
+		],
+		"sort": [{"sortBy": "name", "sortOrder": "ASC"}],
+		"expand": ["routingStatus"],
+		"pageSize": 2,
+		"pageNumber": 1
+	}`, string(server.Requests[0].Body))
+
+	count := 0
+	for range client.SearchUsers(context.Background(), request) {
+		count++
+		break
+	}
+	suite.Assert().Equal(1, count)
+	suite.Assert().Len(server.Requests, 3, "Stopping the loop should stop fetching pages")
+}
+
+func (suite *SearchSuite) TestCanSearchGroups() {
+	groupID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/groups/search": map[string]any{"total": 1, "pageCount": 1, "results": []map[string]any{{"id": groupID, "name": "Supervisors"}}},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	request := gcloudcx.NewSearchRequest(gcloudcx.SearchRange("2026-01-01T00:00:00Z", "", "dateModified")).Where(gcloudcx.SearchQueryString("super*"))
+	groups := []*gcloudcx.Group{}
+	for group, err := range client.SearchGroups(context.Background(), request) {
+		suite.Require().NoErrorf(err, "Failed to search groups. %s", err)
+		groups = append(groups, group)
+	}
+	suite.Require().Len(groups, 1)
+	suite.Assert().Equal(groupID, groups[0].GetID())
+}
+
+func (suite *SearchSuite) TestShouldNotSearchWithInvalidRequest() {
+	suite.Assert().Error(gcloudcx.NewSearchRequest().Validate(), "A search without criteria should fail")
+	suite.Assert().Error(gcloudcx.NewSearchRequest(gcloudcx.SearchExact("doe")).Validate(), "An exact search without fields should fail")
+	suite.Assert().Error(gcloudcx.NewSearchRequest(gcloudcx.SearchRange("", "", "dateModified")).Validate(), "A range without values should fail")
+	suite.Assert().Error(gcloudcx.NewSearchRequest(gcloudcx.SearchOr(gcloudcx.SearchContains("", "name"))).Validate(), "Invalid criteria in groups should fail")
+	suite.Assert().Error(gcloudcx.NewSearchRequest(gcloudcx.SearchQueryString("doe")).WithPageSize(500).Validate(), "A page size above 100 should fail")
+
+	client := CreateTestClient("http://localhost", suite.Logger)
+	for _, err := range client.SearchUsers(context.Background(), gcloudcx.NewSearchRequest()) {
+		suite.Assert().Error(err, "Searching with an invalid request should fail")
+	}
+}
@@ -0,0 +1,159 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// Station describes a Station, the phone agents use to handle calls
+//
+// See: https://developer.genesys.cloud/telephony/stations-apis
+type Station struct {
+	ID               uuid.UUID        `json:"id"`
+	Name             string           `json:"name"`
+	Description      string           `json:"description,omitempty"`
+	Status           string           `json:"status,omitempty"` // AVAILABLE, ASSOCIATED
+	Type             string           `json:"type,omitempty"`   // inin_webrtc_softphone, inin_remote, ...
+	UserID           string           `json:"userId,omitempty"` // the user associated to this station
+	WebRTCUserID     string           `json:"webRtcUserId,omitempty"`
+	LineAppearanceID string           `json:"lineAppearanceId,omitempty"`
+	PrimaryEdge      *DomainEntityRef `json:"primaryEdge,omitempty"`
+	SecondaryEdge    *DomainEntityRef `json:"secondaryEdge,omitempty"`
+	SelfURI          URI              `json:"selfUri,omitempty"`
+	client           *Client          `json:"-"`
+	logger           *logger.Logger   `json:"-"`
+}
+
+// Station statuses
+const (
+	StationStatusAvailable  = "AVAILABLE"
+	StationStatusAssociated = "ASSOCIATED"
+)
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (station *Station) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			station.ID = parameter
+		case *Client:
+			station.client = parameter
+		case *logger.Logger:
+			station.logger = parameter.Child("station", "station", "id", station.ID)
+		}
+	}
+	if station.logger == nil {
+		station.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (station Station) GetID() uuid.UUID {
+	return station.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (station Station) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/stations/%s", ids[0])
+	}
+	if station.ID != uuid.Nil {
+		return NewURI("/api/v2/stations/%s", station.ID)
+	}
+	return URI("/api/v2/stations/")
+}
+
+// GetName gets the name of this
+//
+// implements Named
+func (station Station) GetName() string {
+	return station.Name
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (station Station) String() string {
+	if len(station.Name) > 0 {
+		return station.Name
+	}
+	return station.ID.String()
+}
+
+// Disassociate disassociates the user associated to this Station
+func (station Station) Disassociate(context context.Context) (correlationID string, err error) {
+	if err = station.checkInitialized(); err != nil {
+		return
+	}
+	return station.client.Delete(station.logger.ToContext(context), NewURI("/stations/%s/associateduser", station.ID), nil)
+}
+
+// checkInitialized checks if this Station can send requests
+func (station Station) checkInitialized() error {
+	return checkInitialized(station.client, "Station", station.ID)
+}
+
+// FetchStationByLine fetches the Station of a phone line (its line appearance)
+func (client *Client) FetchStationByLine(context context.Context, lineID uuid.UUID) (*Station, string, error) {
+	if lineID == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("lineId")
+	}
+	return client.fetchStation(context, Query{"lineAppearanceId": lineID.String()})
+}
+
+// FetchStationByWebRTCUser fetches the WebRTC Station of a User
+func (client *Client) FetchStationByWebRTCUser(context context.Context, user Identifiable) (*Station, string, error) {
+	if user == nil || user.GetID() == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("user")
+	}
+	return client.fetchStation(context, Query{"webRtcUserId": user.GetID().String()})
+}
+
+// FetchStationByPhone fetches the Station of a phone (e.g. a WebRTC phone)
+//
+// The station is found with the first line of the phone.
+func (client *Client) FetchStationByPhone(context context.Context, phoneID uuid.UUID) (*Station, string, error) {
+	if phoneID == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("phoneId")
+	}
+	phone := struct {
+		Lines []EntityRef `json:"lines"`
+	}{}
+	correlationID, err := client.Get(context, NewURI("/telephony/providers/edges/phones/%s", phoneID), &phone)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	if len(phone.Lines) == 0 {
+		return nil, correlationID, errors.NotFound.With("line of phone", phoneID)
+	}
+	return client.FetchStationByLine(context, phone.Lines[0].ID)
+}
+
+// fetchStation fetches the first Station that matches the query
+func (client *Client) fetchStation(context context.Context, query Query) (*Station, string, error) {
+	entities, correlationID, err := client.FetchEntities(context, NewURI("/stations").WithQuery(query))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	if len(entities) == 0 {
+		return nil, correlationID, errors.NotFound.With("station", query.Encode())
+	}
+	station := &Station{}
+	if err := json.Unmarshal(entities[0], station); err != nil {
+		return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+	}
+	station.Initialize(client, client.Logger)
+	return station, correlationID, nil
+}
@@ -0,0 +1,181 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type StationSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestStationSuite(t *testing.T) {
+	suite.Run(t, new(StationSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *StationSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *StationSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *StationSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *StationSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *StationSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *StationSuite) TestCanResolveStationFromPhone() {
+	phoneID := uuid.New()
+	lineID := uuid.New()
+	stationID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/telephony/providers/edges/phones/%s", phoneID): map[string]any{"id": phoneID, "lines": []map[string]any{{"id": lineID}}},
+		"GET /api/v2/stations": map[string]any{
+			"entities":  []map[string]any{{"id": stationID, "name": "WebRTC - John", "status": "AVAILABLE", "type": "inin_webrtc_softphone", "lineAppearanceId": lineID}},
+			"pageCount": 1,
+		},
+		fmt.Sprintf("DELETE /api/v2/stations/%s/associateduser", stationID): nil,
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	station, _, err := client.FetchStationByPhone(context.Background(), phoneID)
+	suite.Require().NoErrorf(err, "Failed to fetch station. %s", err)
+	suite.Assert().Equal(stationID, station.ID)
+	suite.Assert().Equal(gcloudcx.StationStatusAvailable, station.Status)
+	suite.Assert().Equal(lineID.String(), server.LastRequest().Query.Get("lineAppearanceId"))
+
+	_, err = station.Disassociate(context.Background())
+	suite.Require().NoErrorf(err, "Failed to disassociate station. %s", err)
+	suite.Assert().Equal(http.MethodDelete, server.LastRequest().Method)
+}
+
+func (suite *StationSuite) TestShouldFailResolvingStationWithoutLine() {
+	phoneID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/telephony/providers/edges/phones/%s", phoneID): map[string]any{"id": phoneID, "lines": []map[string]any{}},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	_, _, err := client.FetchStationByPhone(context.Background(), phoneID)
+	suite.Require().Error(err)
+	suite.Assert().ErrorIs(err, errors.NotFound)
+}
+
+func (suite *StationSuite) TestCanEnsureStation() {
+	userID := uuid.New()
+	stationID := uuid.New()
+	defaultID := uuid.New()
+	associated := false
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PUT /api/v2/users/%s/station/associatedstation/%s", userID, stationID): http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			associated = true
+			w.WriteHeader(http.StatusAccepted)
+		}),
+		fmt.Sprintf("GET /api/v2/users/%s/station", userID): http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			effective := defaultID
+			if associated {
+				effective = stationID
+			}
+			core.RespondWithJSON(w, http.StatusOK, map[string]any{
+				"defaultStation":   map[string]any{"id": defaultID},
+				"effectiveStation": map[string]any{"id": effective},
+			})
+		}),
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+	station := gcloudcx.Station{ID: stationID}
+
+	stations, _, err := user.EnsureStation(context.Background(), station)
+	suite.Require().NoErrorf(err, "Failed to ensure station. %s", err)
+	suite.Assert().Equal(stationID, stations.EffectiveStation.ID)
+	suite.Assert().Len(server.Requests, 3)
+
+	stations, _, err = user.EnsureStation(context.Background(), station)
+	suite.Require().NoErrorf(err, "Failed to ensure station. %s", err)
+	suite.Assert().Equal(stationID, stations.EffectiveStation.ID)
+	suite.Assert().Len(server.Requests, 4, "The station should not be associated again")
+}
+
+func (suite *StationSuite) TestCanManageDefaultStation() {
+	userID := uuid.New()
+	stationID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PUT /api/v2/users/%s/station/defaultstation/%s", userID, stationID): nil,
+		fmt.Sprintf("DELETE /api/v2/users/%s/station/defaultstation", userID):            nil,
+		fmt.Sprintf("DELETE /api/v2/users/%s/station/associatedstation", userID):         nil,
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+
+	_, err := user.SetDefaultStation(context.Background(), gcloudcx.Station{ID: stationID})
+	suite.Require().NoErrorf(err, "Failed to set default station. %s", err)
+	_, err = user.ClearDefaultStation(context.Background())
+	suite.Require().NoErrorf(err, "Failed to clear default station. %s", err)
+	_, err = user.DisassociateStation(context.Background())
+	suite.Require().NoErrorf(err, "Failed to disassociate station. %s", err)
+	suite.Assert().Len(server.Requests, 3)
+
+	_, err = user.SetDefaultStation(context.Background(), nil)
+	suite.Assert().ErrorIs(err, errors.ArgumentMissing)
+}
@@ -0,0 +1,323 @@
+package gcloudcx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// Transcript describes the chronological transcript of a Conversation
+//
+// It merges the chat, messaging, and email recordings of the Conversation.
+// Use Redact to get a copy without personal information.
+type Transcript struct {
+	ConversationID uuid.UUID         `json:"conversationId"`
+	Entries        []TranscriptEntry `json:"entries"`
+}
+
+// TranscriptEntry describes a message of a Transcript
+type TranscriptEntry struct {
+	Time        time.Time      `json:"time"`
+	Role        TranscriptRole `json:"role"`
+	Name        string         `json:"name,omitempty"`
+	Media       string         `json:"media"` // chat, messaging, email
+	Subject     string         `json:"subject,omitempty"`
+	Text        string         `json:"text"`
+	Attachments []string       `json:"attachments,omitempty"`
+}
+
+// TranscriptRole describes who wrote a TranscriptEntry
+type TranscriptRole string
+
+// Transcript roles
+const (
+	TranscriptRoleCustomer TranscriptRole = "customer"
+	TranscriptRoleAgent    TranscriptRole = "agent"
+	TranscriptRoleBot      TranscriptRole = "bot"
+	TranscriptRoleSystem   TranscriptRole = "system"
+)
+
+// TranscriptFormat describes the format a Transcript is rendered to
+type TranscriptFormat string
+
+// Transcript formats
+const (
+	TranscriptFormatText     TranscriptFormat = "text"
+	TranscriptFormatMarkdown TranscriptFormat = "markdown"
+	TranscriptFormatHTML     TranscriptFormat = "html"
+	TranscriptFormatJSON     TranscriptFormat = "json"
+)
+
+// FetchTranscript fetches the recordings of this Conversation and merges them in a Transcript
+//
+// The participants of this Conversation are used to recognize the customer's email addresses.
+func (conversation Conversation) FetchTranscript(context context.Context) (*Transcript, string, error) {
+	recordings, correlationID, err := conversation.FetchRecordings(context)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return NewTranscript(conversation, recordings...), correlationID, nil
+}
+
+// NewTranscript merges the chat, messaging, and email transcripts of the given recordings
+//
+// The entries are sorted chronologically. Audio and screen recordings are ignored.
+func NewTranscript(conversation Conversation, recordings ...Recording) *Transcript {
+	transcript := &Transcript{ConversationID: conversation.ID, Entries: []TranscriptEntry{}}
+	customerAddresses := map[string]bool{}
+	for _, participant := range conversation.Participants {
+		if transcriptRoleOf(string(participant.Purpose)) == TranscriptRoleCustomer && len(participant.Address) > 0 {
+			customerAddresses[strings.ToLower(participant.Address)] = true
+		}
+	}
+
+	for _, recording := range recordings {
+		if transcript.ConversationID == uuid.Nil {
+			transcript.ConversationID = recording.ConversationID
+		}
+		for _, message := range recording.Transcript {
+			transcript.Entries = append(transcript.Entries, newChatTranscriptEntry(message))
+		}
+		for _, message := range recording.MessagingTranscript {
+			if entry, ok := newMessagingTranscriptEntry(message); ok {
+				transcript.Entries = append(transcript.Entries, entry)
+			}
+		}
+		for index, message := range recording.EmailTranscript {
+			if len(customerAddresses) == 0 && index == 0 && len(message.From.Email) > 0 {
+				// Without participants, the sender of the first email is deemed to be the customer
+				customerAddresses[strings.ToLower(message.From.Email)] = true
+			}
+			transcript.Entries = append(transcript.Entries, newEmailTranscriptEntry(message, customerAddresses))
+		}
+	}
+	sort.SliceStable(transcript.Entries, func(i, j int) bool {
+		return transcript.Entries[i].Time.Before(transcript.Entries[j].Time)
+	})
+	return transcript
+}
+
+// Render renders this Transcript to the given writer in the given format
+func (transcript Transcript) Render(writer io.Writer, format TranscriptFormat) error {
+	if writer == nil {
+		return errors.ArgumentMissing.With("writer")
+	}
+	var builder strings.Builder
+	switch format {
+	case TranscriptFormatText, "":
+		for _, entry := range transcript.Entries {
+			fmt.Fprintf(&builder, "[%s] %s", entry.Time.UTC().Format(time.RFC3339), entry.label())
+			if len(entry.Subject) > 0 {
+				fmt.Fprintf(&builder, " (Subject: %s)", entry.Subject)
+			}
+			fmt.Fprintf(&builder, ": %s\n", entry.Text)
+			for _, attachment := range entry.Attachments {
+				fmt.Fprintf(&builder, "    Attachment: %s\n", attachment)
+			}
+		}
+	case TranscriptFormatMarkdown:
+		fmt.Fprintf(&builder, "# Conversation %s\n", transcript.ConversationID)
+		for _, entry := range transcript.Entries {
+			fmt.Fprintf(&builder, "\n**%s** _%s_\n", entry.label(), entry.Time.UTC().Format(time.RFC3339))
+			if len(entry.Subject) > 0 {
+				fmt.Fprintf(&builder, "\n**Subject:** %s\n", entry.Subject)
+			}
+			builder.WriteString("\n")
+			for _, line := range strings.Split(entry.Text, "\n") {
+				fmt.Fprintf(&builder, "> %s\n", line)
+			}
+			for _, attachment := range entry.Attachments {
+				fmt.Fprintf(&builder, "- Attachment: %s\n", attachment)
+			}
+		}
+	case TranscriptFormatHTML:
+		fmt.Fprintf(&builder, "<div class=\"transcript\" data-conversation=\"%s\">\n", transcript.ConversationID)
+		for _, entry := range transcript.Entries {
+			fmt.Fprintf(&builder, "  <div class=\"entry %s\">\n", html.EscapeString(string(entry.Role)))
+			fmt.Fprintf(&builder, "    <span class=\"time\">%s</span> <span class=\"name\">%s</span>\n", entry.Time.UTC().Format(time.RFC3339), html.EscapeString(entry.label()))
+			if len(entry.Subject) > 0 {
+				fmt.Fprintf(&builder, "    <p class=\"subject\">%s</p>\n", html.EscapeString(entry.Subject))
+			}
+			fmt.Fprintf(&builder, "    <p class=\"text\">%s</p>\n", strings.ReplaceAll(html.EscapeString(entry.Text), "\n", "<br/>"))
+			for _, attachment := range entry.Attachments {
+				fmt.Fprintf(&builder, "    <p class=\"attachment\">%s</p>\n", html.EscapeString(attachment))
+			}
+			builder.WriteString("  </div>\n")
+		}
+		builder.WriteString("</div>\n")
+	case TranscriptFormatJSON:
+		data, err := json.MarshalIndent(transcript, "", "  ")
+		if err != nil {
+			return errors.JSONMarshalError.Wrap(err)
+		}
+		builder.Write(data)
+		builder.WriteString("\n")
+	default:
+		return errors.ArgumentInvalid.With("format", format, "text, markdown, html, json")
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (transcript Transcript) String() string {
+	var builder strings.Builder
+	_ = transcript.Render(&builder, TranscriptFormatText)
+	return builder.String()
+}
+
+// Redact redacts sensitive data
+//
+// implements logger.Redactable
+func (transcript Transcript) Redact() interface{} {
+	redacted := transcript
+	redacted.Entries = make([]TranscriptEntry, 0, len(transcript.Entries))
+	for _, entry := range transcript.Entries {
+		redacted.Entries = append(redacted.Entries, entry.Redact().(TranscriptEntry))
+	}
+	return redacted
+}
+
+// Redact redacts sensitive data
+//
+// The text, subject, and attachments are redacted, as well as the name of the customer.
+//
+// implements logger.Redactable
+func (entry TranscriptEntry) Redact() interface{} {
+	redacted := entry
+	if len(entry.Text) > 0 {
+		redacted.Text = logger.RedactWithHash(entry.Text)
+	}
+	if len(entry.Subject) > 0 {
+		redacted.Subject = logger.RedactWithHash(entry.Subject)
+	}
+	if entry.Role == TranscriptRoleCustomer && len(entry.Name) > 0 {
+		redacted.Name = logger.RedactWithHash(entry.Name)
+	}
+	if len(entry.Attachments) > 0 {
+		redacted.Attachments = make([]string, 0, len(entry.Attachments))
+		for _, attachment := range entry.Attachments {
+			redacted.Attachments = append(redacted.Attachments, logger.RedactWithHash(attachment))
+		}
+	}
+	return redacted
+}
+
+// label gets the label of the entry's author
+//
+// Entries without a role are labeled as system entries
+func (entry TranscriptEntry) label() string {
+	role := string(entry.Role)
+	if len(role) == 0 {
+		role = string(TranscriptRoleSystem)
+	}
+	role = strings.ToUpper(role[:1]) + role[1:]
+	if len(entry.Name) > 0 {
+		return role + " (" + entry.Name + ")"
+	}
+	return role
+}
+
+// transcriptRoleOf gets the TranscriptRole of a participant purpose
+func transcriptRoleOf(purpose string) TranscriptRole {
+	switch ParticipantPurpose(strings.ToLower(purpose)) {
+	case ParticipantPurposeCustomer, ParticipantPurposeExternal:
+		return TranscriptRoleCustomer
+	case ParticipantPurposeAgent, ParticipantPurposeUser:
+		return TranscriptRoleAgent
+	case ParticipantPurposeBot, ParticipantPurposeWorkflow, ParticipantPurposeIVR:
+		return TranscriptRoleBot
+	}
+	return TranscriptRoleSystem
+}
+
+func newChatTranscriptEntry(message RecordingChatMessage) TranscriptEntry {
+	entry := TranscriptEntry{
+		Time:  parseTranscriptTime(message.UTC),
+		Role:  transcriptRoleOf(message.ParticipantPurpose),
+		Name:  message.From,
+		Media: "chat",
+		Text:  message.Body,
+	}
+	if message.User != nil && len(message.User.Name) > 0 {
+		entry.Name = message.User.Name
+	}
+	if len(message.BodyType) > 0 && !strings.EqualFold(message.BodyType, "standard") {
+		entry.Role = TranscriptRoleSystem
+	}
+	return entry
+}
+
+func newMessagingTranscriptEntry(message RecordingMessagingMessage) (TranscriptEntry, bool) {
+	entry := TranscriptEntry{
+		Time:  message.Timestamp,
+		Role:  transcriptRoleOf(message.Purpose),
+		Name:  message.FromUser.Name,
+		Media: "messaging",
+		Text:  message.MessageText,
+	}
+	if len(entry.Name) == 0 && entry.Role != TranscriptRoleAgent {
+		entry.Name = message.FromExternalContact.Name
+	}
+	for _, media := range message.MediaAttachments {
+		if len(media.Name) > 0 {
+			entry.Attachments = append(entry.Attachments, media.Name)
+		} else if media.URL != nil {
+			entry.Attachments = append(entry.Attachments, media.URL.String())
+		}
+	}
+	return entry, len(entry.Text) > 0 || len(entry.Attachments) > 0
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+func newEmailTranscriptEntry(message RecordingEmailMessage, customerAddresses map[string]bool) TranscriptEntry {
+	entry := TranscriptEntry{
+		Time:    parseTranscriptTime(message.Time),
+		Role:    TranscriptRoleAgent,
+		Name:    message.From.Name,
+		Media:   "email",
+		Subject: message.Subject,
+		Text:    message.TextBody,
+	}
+	if customerAddresses[strings.ToLower(message.From.Email)] {
+		entry.Role = TranscriptRoleCustomer
+	}
+	if len(entry.Name) == 0 {
+		entry.Name = message.From.Email
+	}
+	if len(entry.Text) == 0 {
+		entry.Text = message.Body
+	}
+	if len(entry.Text) == 0 && len(message.HTMLBody) > 0 {
+		entry.Text = strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(message.HTMLBody, "")))
+	}
+	for _, attachment := range message.Attachments {
+		entry.Attachments = append(entry.Attachments, attachment.Name)
+	}
+	return entry
+}
+
+// parseTranscriptTime parses the times of recording transcripts, which are either RFC 3339 strings or epoch milliseconds
+func parseTranscriptTime(value string) time.Time {
+	if milliseconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(milliseconds).UTC()
+	}
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return parsed.UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,200 @@
+package gcloudcx_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type TranscriptSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestTranscriptSuite(t *testing.T) {
+	suite.Run(t, new(TranscriptSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *TranscriptSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *TranscriptSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *TranscriptSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *TranscriptSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *TranscriptSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *TranscriptSuite) CreateConversation() (gcloudcx.Conversation, []gcloudcx.Recording) {
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	conversation := gcloudcx.Conversation{
+		ID: uuid.New(),
+		Participants: []gcloudcx.Participant{
+			{ID: uuid.New(), Purpose: gcloudcx.ParticipantPurposeCustomer, Address: "john@acme.com"},
+			{ID: uuid.New(), Purpose: gcloudcx.ParticipantPurposeAgent, Address: "support@example.com"},
+		},
+	}
+	recordings := []gcloudcx.Recording{
+		{
+			ConversationID: conversation.ID,
+			Transcript: []gcloudcx.RecordingChatMessage{
+				{From: "John", Body: "Hello, I need help", UTC: fmt.Sprintf("%d", start.Add(2*time.Minute).UnixMilli()), BodyType: "standard", ParticipantPurpose: "customer"},
+				{From: "Agent", Body: "Agent joined", UTC: start.Add(3 * time.Minute).Format(time.RFC3339), BodyType: "member-join", ParticipantPurpose: "agent"},
+				{From: "Agent", Body: "Sure, how can I help?", UTC: start.Add(4 * time.Minute).Format(time.RFC3339), BodyType: "standard", ParticipantPurpose: "agent", User: &gcloudcx.User{Name: "Jane Agent"}},
+			},
+			MessagingTranscript: []gcloudcx.RecordingMessagingMessage{
+				{Purpose: "workflow", MessageText: "Welcome to ACME", Timestamp: start.Add(time.Minute)},
+				{Purpose: "customer", FromExternalContact: gcloudcx.DomainEntityRef{Name: "John"}, Timestamp: start.Add(5 * time.Minute), MediaAttachments: []gcloudcx.Media{{Name: "invoice.pdf"}}},
+			},
+			EmailTranscript: []gcloudcx.RecordingEmailMessage{
+				{From: gcloudcx.EmailAddress{Name: "John", Email: "John@acme.com"}, Subject: "My order", Time: start.Format(time.RFC3339), HTMLBody: "<p>Where is my order &amp; invoice?</p>"},
+				{From: gcloudcx.EmailAddress{Email: "support@example.com"}, Subject: "Re: My order", Time: start.Add(6 * time.Minute).Format(time.RFC3339), TextBody: "It is on its way"},
+			},
+		},
+	}
+	return conversation, recordings
+}
+
+func (suite *TranscriptSuite) TestCanMergeRecordings() {
+	conversation, recordings := suite.CreateConversation()
+	transcript := gcloudcx.NewTranscript(conversation, recordings...)
+	suite.Assert().Equal(conversation.ID, transcript.ConversationID)
+	suite.Require().Len(transcript.Entries, 7)
+
+	expected := []struct {
+		Role  gcloudcx.TranscriptRole
+		Media string
+		Text  string
+	}{
+		{gcloudcx.TranscriptRoleCustomer, "email", "Where is my order & invoice?"},
+		{gcloudcx.TranscriptRoleBot, "messaging", "Welcome to ACME"},
+		{gcloudcx.TranscriptRoleCustomer, "chat", "Hello, I need help"},
+		{gcloudcx.TranscriptRoleSystem, "chat", "Agent joined"},
+		{gcloudcx.TranscriptRoleAgent, "chat", "Sure, how can I help?"},
+		{gcloudcx.TranscriptRoleCustomer, "messaging", ""},
+		{gcloudcx.TranscriptRoleAgent, "email", "It is on its way"},
+	}
+	for index, entry := range transcript.Entries {
+		suite.Assert().Equalf(expected[index].Role, entry.Role, "Entry %d has the wrong role", index)
+		suite.Assert().Equalf(expected[index].Media, entry.Media, "Entry %d has the wrong media", index)
+		suite.Assert().Equalf(expected[index].Text, entry.Text, "Entry %d has the wrong text", index)
+	}
+	suite.Assert().Equal("Jane Agent", transcript.Entries[4].Name)
+	suite.Assert().Equal([]string{"invoice.pdf"}, transcript.Entries[5].Attachments)
+}
+
+func (suite *TranscriptSuite) TestCanRenderTranscript() {
+	conversation, recordings := suite.CreateConversation()
+	transcript := gcloudcx.NewTranscript(conversation, recordings...)
+
+	var text strings.Builder
+	suite.Require().NoError(transcript.Render(&text, gcloudcx.TranscriptFormatText))
+	suite.Assert().Contains(text.String(), "[2026-05-01T09:04:00Z] Agent (Jane Agent): Sure, how can I help?\n")
+	suite.Assert().Contains(text.String(), "[2026-05-01T09:00:00Z] Customer (John) (Subject: My order): Where is my order & invoice?\n")
+	suite.Assert().Equal(text.String(), transcript.String())
+
+	var markdown strings.Builder
+	suite.Require().NoError(transcript.Render(&markdown, gcloudcx.TranscriptFormatMarkdown))
+	suite.Assert().Contains(markdown.String(), "# Conversation "+conversation.ID.String())
+	suite.Assert().Contains(markdown.String(), "**Bot** _2026-05-01T09:01:00Z_\n\n> Welcome to ACME\n")
+
+	var page strings.Builder
+	suite.Require().NoError(transcript.Render(&page, gcloudcx.TranscriptFormatHTML))
+	suite.Assert().Contains(page.String(), `<div class="entry customer">`)
+	suite.Assert().Contains(page.String(), `<p class="text">Where is my order &amp; invoice?</p>`)
+
+	var payload strings.Builder
+	suite.Require().NoError(transcript.Render(&payload, gcloudcx.TranscriptFormatJSON))
+	var decoded gcloudcx.Transcript
+	suite.Require().NoError(json.Unmarshal([]byte(payload.String()), &decoded))
+	suite.Assert().Len(decoded.Entries, 7)
+
+	suite.Assert().Error(transcript.Render(&payload, "pdf"), "Rendering to an unknown format should fail")
+}
+
+func (suite *TranscriptSuite) TestCanRenderEntryWithoutRole() {
+	transcript := gcloudcx.Transcript{}
+	err := json.Unmarshal([]byte(`{"conversationId": "aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e", "entries": [{"time": "2026-05-01T09:00:00Z", "text": "Call recorded"}]}`), &transcript)
+	suite.Require().NoErrorf(err, "Failed to unmarshal transcript. %s", err)
+	suite.Require().NotPanics(func() { _ = transcript.String() })
+	suite.Assert().Contains(transcript.String(), "System: Call recorded")
+	for _, format := range []gcloudcx.TranscriptFormat{gcloudcx.TranscriptFormatMarkdown, gcloudcx.TranscriptFormatHTML} {
+		var output strings.Builder
+		suite.Require().NotPanics(func() { suite.Assert().NoError(transcript.Render(&output, format)) })
+	}
+}
+
+func (suite *TranscriptSuite) TestShouldEscapeRoleInHTML() {
+	transcript := gcloudcx.Transcript{}
+	err := json.Unmarshal([]byte(`{"conversationId": "aa06a6fc-1fdf-4e59-b8a1-df3ca44f523e", "entries": [{"time": "2026-05-01T09:00:00Z", "role": "customer\"><script>alert(1)</script>", "text": "Hello"}]}`), &transcript)
+	suite.Require().NoErrorf(err, "Failed to unmarshal transcript. %s", err)
+	var output strings.Builder
+	suite.Require().NoError(transcript.Render(&output, gcloudcx.TranscriptFormatHTML))
+	suite.Assert().NotContains(output.String(), "<script>", "The role should be escaped")
+	suite.Assert().Contains(output.String(), "&lt;script&gt;")
+}
+
+func (suite *TranscriptSuite) TestCanRedactTranscript() {
+	conversation, recordings := suite.CreateConversation()
+	transcript := gcloudcx.NewTranscript(conversation, recordings...)
+	redacted, ok := transcript.Redact().(gcloudcx.Transcript)
+	suite.Require().True(ok, "Redact should return a Transcript")
+	suite.Require().Len(redacted.Entries, len(transcript.Entries))
+	suite.Assert().NotContains(redacted.String(), "Where is my order")
+	suite.Assert().NotContains(redacted.String(), "invoice.pdf")
+	suite.Assert().Equal("Jane Agent", redacted.Entries[4].Name, "Agent names should not be redacted")
+	suite.Assert().NotEqual("John", redacted.Entries[0].Name, "Customer names should be redacted")
+	suite.Assert().Equal("Where is my order & invoice?", transcript.Entries[0].Text, "The original transcript should not be modified")
+}
@@ -59,7 +59,7 @@ type FetchableByStringID interface {
 
 // StateUpdater describes objects than can update the state of an Identifiable
 type StateUpdater interface {
-	UpdateState(context context.Context, identifiable Identifiable, state string) (correlationID string, err error)
+	UpdateState(context context.Context, identifiable Identifiable, state ConversationState) (correlationID string, err error)
 }
 
 // Disconnecter describes objects that can disconnect an Identifiable from themselves
@@ -72,6 +72,16 @@ type Transferrer interface {
 	Transfer(context context.Context, identifiable Identifiable, target Identifiable) (correlationID string, err error)
 }
 
+// WrapUpper describes objects that can wrap up an Identifiable
+type WrapUpper interface {
+	Wrapup(context context.Context, identifiable Identifiable, wrapup *Wrapup) (correlationID string, err error)
+}
+
+// AttributesUpdater describes objects that can update the attributes of an Identifiable
+type AttributesUpdater interface {
+	UpdateAttributes(context context.Context, identifiable Identifiable, attributes map[string]string) (correlationID string, err error)
+}
+
 // Address describes an Address (telno, etc)
 type Address struct {
 	Name               string `json:"name"`
@@ -0,0 +1,46 @@
+package gcloudcx
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-request"
+)
+
+// UploadURL describes a pre-signed URL given by Genesys Cloud to upload some content
+type UploadURL struct {
+	URL       string            `json:"url"`
+	UploadKey string            `json:"uploadKey"`
+	Headers   map[string]string `json:"headers"`
+}
+
+// Upload sends the given content to a pre-signed URL
+//
+// The access token of the Client is not sent since the URL carries its own credentials.
+func (client *Client) Upload(context context.Context, upload UploadURL, content *request.Content) (err error) {
+	if len(upload.URL) == 0 {
+		return errors.ArgumentMissing.With("url")
+	}
+	if content == nil {
+		return errors.ArgumentMissing.With("content")
+	}
+	log := client.GetLogger(context).Child(nil, "upload")
+	uri := URI(upload.URL)
+	options := &request.Options{
+		Context:  context,
+		Method:   http.MethodPut,
+		Headers:  upload.Headers,
+		Payload:  content,
+		Proxy:    client.Proxy,
+		Timeout:  client.RequestTimeout,
+		Logger:   log,
+		Attempts: 1,
+	}
+	if options.URL, err = uri.URL(); err != nil {
+		return errors.WithStack(APIError{Code: "url.parse", Message: err.Error()})
+	}
+	log.Debugf("Uploading %d bytes of %s", content.Length, content.Type)
+	_, err = request.Send(options, nil)
+	return err
+}
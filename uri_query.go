@@ -9,9 +9,17 @@ import (
 type Query map[string]interface{}
 
 // Encode returns the query as a "URL encoded" string
+//
+// Values that are []string are encoded as repeated keys
 func (query Query) Encode() string {
 	values := url.Values{}
 	for key, value := range query {
+		if list, ok := value.([]string); ok {
+			for _, item := range list {
+				values.Add(key, item)
+			}
+			continue
+		}
 		values.Set(key, fmt.Sprintf("%v", value))
 	}
 	return values.Encode()
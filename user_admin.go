@@ -0,0 +1,278 @@
+package gcloudcx
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// UserUpdate describes the changes to apply to a User
+//
+// Empty fields are left untouched.
+//
+// See: https://developer.genesys.cloud/useragentman/users/#patch-api-v2-users--userId-
+type UserUpdate struct {
+	ID            uuid.UUID // The User to update, only used by Client.UpdateUsers
+	Name          string
+	PreferredName string
+	Department    string
+	Title         string
+	Email         string
+	Manager       Identifiable
+	State         string // active, inactive
+}
+
+// UserResult describes the result of a bulk operation for one User
+type UserResult struct {
+	ID            uuid.UUID
+	User          *User // nil if the operation failed or did not return a User
+	CorrelationID string
+	Error         error
+}
+
+// UserResults describes the results of a bulk operation
+type UserResults []UserResult
+
+// User states
+const (
+	UserStateActive   = "active"
+	UserStateInactive = "inactive"
+	UserStateDeleted  = "deleted"
+)
+
+// userUpdateAttempts is how many times an update is attempted when the user is modified concurrently
+const userUpdateAttempts = 3
+
+// CreateUser creates a User
+func (client *Client) CreateUser(context context.Context, request *UserRequest) (*User, string, error) {
+	if request == nil {
+		return nil, "", errors.ArgumentMissing.With("request")
+	}
+	if err := request.Validate(); err != nil {
+		return nil, "", err
+	}
+	user := &User{}
+	correlationID, err := client.Post(context, NewURI("/users"), request, user)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	user.Initialize(client, client.Logger)
+	return user, correlationID, nil
+}
+
+// Update updates this User
+//
+// The Version of this User is sent with the update. If the User was modified in the meantime,
+// its current version is fetched and the update is attempted again.
+//
+// The updated User is returned.
+func (user User) Update(context context.Context, update UserUpdate) (*User, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	version := user.Version
+	var correlationID string
+	var err error
+	for attempt := 1; attempt <= userUpdateAttempts; attempt++ {
+		updated := &User{}
+		correlationID, err = user.client.Patch(user.logger.ToContext(context), NewURI("/users/%s", user.ID), update.patch(version), updated)
+		if err == nil {
+			updated.Initialize(user.client, user.client.Logger)
+			return updated, correlationID, nil
+		}
+		if !isConflictError(err) || attempt == userUpdateAttempts {
+			return nil, correlationID, err
+		}
+		user.logger.Warnf("User %s was modified concurrently (version %d), fetching its current version", user.ID, version)
+		current, correlationID, fetchErr := Fetch[User](context, user.client, user.ID)
+		if fetchErr != nil {
+			return nil, correlationID, errors.Join(err, fetchErr)
+		}
+		version = current.Version
+	}
+	return nil, correlationID, err
+}
+
+// SetManager sets the manager of this User
+func (user User) SetManager(context context.Context, manager Identifiable) (*User, string, error) {
+	if manager == nil || manager.GetID() == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("manager")
+	}
+	return user.Update(context, UserUpdate{Manager: manager})
+}
+
+// SetDepartment sets the department of this User
+func (user User) SetDepartment(context context.Context, department string) (*User, string, error) {
+	if len(department) == 0 {
+		return nil, "", errors.ArgumentMissing.With("department")
+	}
+	return user.Update(context, UserUpdate{Department: department})
+}
+
+// SetTitle sets the title of this User
+func (user User) SetTitle(context context.Context, title string) (*User, string, error) {
+	if len(title) == 0 {
+		return nil, "", errors.ArgumentMissing.With("title")
+	}
+	return user.Update(context, UserUpdate{Title: title})
+}
+
+// Deactivate deactivates this User
+//
+// A deactivated User cannot log in, but it is kept with its history and can be reactivated.
+func (user User) Deactivate(context context.Context) (*User, string, error) {
+	return user.Update(context, UserUpdate{State: UserStateInactive})
+}
+
+// Reactivate reactivates this User
+func (user User) Reactivate(context context.Context) (*User, string, error) {
+	return user.Update(context, UserUpdate{State: UserStateActive})
+}
+
+// Delete deletes this User
+func (user User) Delete(context context.Context) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	return user.client.Delete(user.logger.ToContext(context), NewURI("/users/%s", user.ID), nil)
+}
+
+// CreateUsers creates several Users
+//
+// The users are created one after the other, the result of each creation is reported in the same order as the requests.
+func (client *Client) CreateUsers(context context.Context, requests ...*UserRequest) UserResults {
+	results := make(UserResults, 0, len(requests))
+	for _, request := range requests {
+		user, correlationID, err := client.CreateUser(context, request)
+		result := UserResult{User: user, CorrelationID: correlationID, Error: err}
+		if user != nil {
+			result.ID = user.ID
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// UpdateUsers updates several Users
+//
+// Each UserUpdate must have the ID of the User to update. The current version of each User is fetched before it is updated.
+func (client *Client) UpdateUsers(context context.Context, updates ...UserUpdate) UserResults {
+	results := make(UserResults, 0, len(updates))
+	for _, update := range updates {
+		results = append(results, client.updateUser(context, update.ID, update))
+	}
+	return results
+}
+
+// DeactivateUsers deactivates several Users
+func (client *Client) DeactivateUsers(context context.Context, users ...Identifiable) UserResults {
+	results := make(UserResults, 0, len(users))
+	for _, user := range users {
+		if user == nil {
+			results = append(results, UserResult{Error: errors.ArgumentMissing.With("user")})
+			continue
+		}
+		results = append(results, client.updateUser(context, user.GetID(), UserUpdate{State: UserStateInactive}))
+	}
+	return results
+}
+
+// DeleteUsers deletes several Users
+func (client *Client) DeleteUsers(context context.Context, users ...Identifiable) UserResults {
+	results := make(UserResults, 0, len(users))
+	for _, identifiable := range users {
+		if identifiable == nil {
+			results = append(results, UserResult{Error: errors.ArgumentMissing.With("user")})
+			continue
+		}
+		user := User{ID: identifiable.GetID()}
+		user.Initialize(client, client.Logger)
+		correlationID, err := user.Delete(context)
+		results = append(results, UserResult{ID: user.ID, CorrelationID: correlationID, Error: err})
+	}
+	return results
+}
+
+// Failed gets the results of the Users the operation failed for
+func (results UserResults) Failed() UserResults {
+	failed := UserResults{}
+	for _, result := range results {
+		if result.Error != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// AsError gets the errors of these results as one error, nil if all operations succeeded
+func (results UserResults) AsError() error {
+	var merr errors.MultiError
+	for _, result := range results {
+		if result.Error != nil {
+			merr.Append(errors.Join(errors.Errorf("User %s", result.ID), result.Error))
+		}
+	}
+	return merr.AsError()
+}
+
+func (client *Client) updateUser(context context.Context, id uuid.UUID, update UserUpdate) UserResult {
+	if id == uuid.Nil {
+		return UserResult{Error: errors.ArgumentMissing.With("id")}
+	}
+	user, correlationID, err := Fetch[User](context, client, id)
+	if err != nil {
+		return UserResult{ID: id, CorrelationID: correlationID, Error: err}
+	}
+	updated, correlationID, err := user.Update(context, update)
+	return UserResult{ID: id, User: updated, CorrelationID: correlationID, Error: err}
+}
+
+// userPatch is the PATCH payload of a UserUpdate
+type userPatch struct {
+	Name          string `json:"name,omitempty"`
+	PreferredName string `json:"preferredName,omitempty"`
+	Department    string `json:"department,omitempty"`
+	Title         string `json:"title,omitempty"`
+	Email         string `json:"email,omitempty"`
+	ManagerID     string `json:"manager,omitempty"`
+	State         string `json:"state,omitempty"`
+	Version       int    `json:"version"`
+}
+
+// patch gets the PATCH payload of this update for the given user version
+func (update UserUpdate) patch(version int) userPatch {
+	return userPatch{
+		Name:          update.Name,
+		PreferredName: update.PreferredName,
+		Department:    update.Department,
+		Title:         update.Title,
+		Email:         update.Email,
+		ManagerID:     identifiableID(update.Manager),
+		State:         update.State,
+		Version:       version,
+	}
+}
+
+// isConflictError tells if the error is an HTTP 409 Conflict
+func isConflictError(err error) bool {
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.Status == http.StatusConflict {
+		return true
+	}
+	return errors.Is(err, errors.HTTPStatusConflict)
+}
+
+func isNotFoundError(err error) bool {
+	var apiError *APIError
+	if errors.As(err, &apiError) && apiError.Status == http.StatusNotFound {
+		return true
+	}
+	return errors.Is(err, errors.HTTPNotFound) || errors.Is(err, errors.NotFound)
+}
+
+// checkInitialized checks if this User can send requests
+func (user User) checkInitialized() error {
+	return checkInitialized(user.client, "User", user.ID)
+}
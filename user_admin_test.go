@@ -0,0 +1,199 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type UserAdminSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestUserAdminSuite(t *testing.T) {
+	suite.Run(t, new(UserAdminSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *UserAdminSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *UserAdminSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *UserAdminSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *UserAdminSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *UserAdminSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *UserAdminSuite) TestCanCreateUser() {
+	userID := uuid.New()
+	divisionID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/users": map[string]any{"id": userID, "name": "John Doe", "email": "john.doe@acme.com", "version": 1},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	request := gcloudcx.NewUserRequest("John Doe", "john.doe@acme.com").
+		WithDepartment("Sales").
+		WithTitle("Account Manager").
+		WithDivision(gcloudcx.Division{ID: divisionID})
+	user, _, err := client.CreateUser(context.Background(), request)
+	suite.Require().NoErrorf(err, "Failed to create user. %s", err)
+	suite.Assert().Equal(userID, user.GetID())
+	suite.Assert().Equal(1, user.Version)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"name": "John Doe", "email": "john.doe@acme.com", "department": "Sales", "title": "Account Manager", "divisionId": "%s"}`, divisionID), string(server.LastRequest().Body))
+
+	suite.Assert().Error(gcloudcx.NewUserRequest("", "john.doe@acme.com").Validate(), "A user without a name should fail")
+	suite.Assert().Error(gcloudcx.NewUserRequest("John Doe", "john.doe").Validate(), "A user with an invalid email should fail")
+}
+
+func (suite *UserAdminSuite) TestCanUpdateUserAfterConflict() {
+	userID := uuid.New()
+	managerID := uuid.New()
+	userPath := fmt.Sprintf("/api/v2/users/%s", userID)
+	patches := 0
+	server := CreateRecordingTestServer(map[string]any{
+		"GET " + userPath: map[string]any{"id": userID, "name": "John Doe", "version": 5},
+		"PATCH " + userPath: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if patches++; patches == 1 {
+				core.RespondWithJSON(w, http.StatusConflict, map[string]any{"status": 409, "code": "updated.resource.conflict", "message": "The version is outdated"})
+				return
+			}
+			core.RespondWithJSON(w, http.StatusOK, map[string]any{"id": userID, "name": "John Doe", "version": 6, "manager": map[string]any{"id": managerID}})
+		}),
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+	user.Version = 4
+
+	updated, _, err := user.SetManager(context.Background(), gcloudcx.User{ID: managerID})
+	suite.Require().NoErrorf(err, "Failed to update user. %s", err)
+	suite.Assert().Equal(6, updated.Version)
+	suite.Assert().Equal(2, patches)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"manager": "%s", "version": 5}`, managerID), string(server.LastRequest().Body))
+
+	_, _, err = updated.Deactivate(context.Background())
+	suite.Require().NoErrorf(err, "Failed to deactivate user. %s", err)
+	suite.Assert().JSONEq(`{"state": "inactive", "version": 6}`, string(server.LastRequest().Body))
+}
+
+func (suite *UserAdminSuite) TestShouldNotFetchUserAfterLastConflict() {
+	userID := uuid.New()
+	userPath := fmt.Sprintf("/api/v2/users/%s", userID)
+	server := CreateRecordingTestServer(map[string]any{
+		"GET " + userPath: map[string]any{"id": userID, "name": "John Doe", "version": 5},
+		"PATCH " + userPath: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			core.RespondWithJSON(w, http.StatusConflict, map[string]any{"status": 409, "code": "updated.resource.conflict", "message": "The version is outdated"})
+		}),
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+
+	_, _, err := user.SetDepartment(context.Background(), "Support")
+	suite.Require().Error(err, "The update should fail when the user keeps being modified")
+	fetches := 0
+	for _, request := range server.Requests {
+		if request.Method == http.MethodGet {
+			fetches++
+		}
+	}
+	suite.Assert().Equal(2, fetches, "The user should not be fetched after the last attempt")
+	suite.Assert().Equal("PATCH", server.LastRequest().Method)
+}
+
+func (suite *UserAdminSuite) TestCanManageUsersInBulk() {
+	users := []uuid.UUID{uuid.New(), uuid.New()}
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/users/%s", users[0]):    map[string]any{"id": users[0], "version": 2},
+		fmt.Sprintf("PATCH /api/v2/users/%s", users[0]):  map[string]any{"id": users[0], "state": "inactive", "version": 3},
+		fmt.Sprintf("DELETE /api/v2/users/%s", users[0]): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	results := client.DeactivateUsers(context.Background(), gcloudcx.User{ID: users[0]}, gcloudcx.User{ID: users[1]})
+	suite.Require().Len(results, 2)
+	suite.Assert().NoError(results[0].Error)
+	suite.Require().NotNil(results[0].User)
+	suite.Assert().Equal("inactive", results[0].User.State)
+	suite.Assert().Error(results[1].Error, "Deactivating an unknown user should fail")
+	suite.Require().Len(results.Failed(), 1)
+	suite.Assert().Equal(users[1], results.Failed()[0].ID)
+	suite.Assert().Error(results.AsError())
+
+	results = client.UpdateUsers(context.Background(), gcloudcx.UserUpdate{ID: users[0], Department: "Support", Title: "Team Lead"})
+	suite.Require().NoError(results.AsError())
+	suite.Assert().JSONEq(`{"department": "Support", "title": "Team Lead", "version": 2}`, string(server.LastRequest().Body))
+
+	results = client.DeleteUsers(context.Background(), gcloudcx.User{ID: users[0]})
+	suite.Require().NoError(results.AsError())
+	suite.Assert().Equal("DELETE", server.LastRequest().Method)
+
+	results = client.DeactivateUsers(context.Background(), nil, gcloudcx.User{ID: users[0]})
+	suite.Require().Len(results, 2)
+	suite.Assert().ErrorIs(results[0].Error, errors.ArgumentMissing, "A nil user should be reported in its result")
+	suite.Assert().NoError(results[1].Error)
+
+	results = client.DeleteUsers(context.Background(), gcloudcx.User{ID: users[0]}, nil)
+	suite.Require().Len(results, 2)
+	suite.Assert().NoError(results[0].Error)
+	suite.Assert().ErrorIs(results[1].Error, errors.ArgumentMissing, "A nil user should be reported in its result")
+}
@@ -22,9 +22,12 @@ type UserPresence struct {
 
 // PresenceDefinition  defines Presence
 type PresenceDefinition struct {
-	ID             uuid.UUID `json:"id"`
-	SystemPresence string    `json:"systemPresence"`
-	SelfURI        URI       `json:"selfUri"`
+	ID             uuid.UUID         `json:"id"`
+	SystemPresence string            `json:"systemPresence"`
+	LanguageLabels map[string]string `json:"languageLabels,omitempty"` // the names of the presence per language, like "en_US"
+	Primary        bool              `json:"primary,omitempty"`
+	Deactivated    bool              `json:"deactivated,omitempty"`
+	SelfURI        URI               `json:"selfUri"`
 }
 
 // GetID gets the identifier of this
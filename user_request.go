@@ -0,0 +1,114 @@
+package gcloudcx
+
+import (
+	"encoding/json"
+	"net/mail"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+)
+
+// UserRequest describes a request to create a User
+//
+// Use NewUserRequest and the With methods to build it.
+//
+// See: https://developer.genesys.cloud/useragentman/users/#post-api-v2-users
+type UserRequest struct {
+	Name       string
+	Email      string
+	Department string
+	Title      string
+	Password   string
+	Division   Identifiable
+	Addresses  []*Contact
+}
+
+// NewUserRequest creates a new UserRequest with the given name and email
+func NewUserRequest(name, email string) *UserRequest {
+	return &UserRequest{Name: name, Email: email}
+}
+
+// WithDepartment sets the department of the user
+func (request *UserRequest) WithDepartment(department string) *UserRequest {
+	request.Department = department
+	return request
+}
+
+// WithTitle sets the title of the user
+func (request *UserRequest) WithTitle(title string) *UserRequest {
+	request.Title = title
+	return request
+}
+
+// WithPassword sets the initial password of the user
+func (request *UserRequest) WithPassword(password string) *UserRequest {
+	request.Password = password
+	return request
+}
+
+// WithDivision sets the division of the user
+func (request *UserRequest) WithDivision(division Identifiable) *UserRequest {
+	request.Division = division
+	return request
+}
+
+// WithAddresses adds contact addresses to the user
+func (request *UserRequest) WithAddresses(addresses ...*Contact) *UserRequest {
+	request.Addresses = append(request.Addresses, addresses...)
+	return request
+}
+
+// Validate validates the user request
+func (request UserRequest) Validate() error {
+	var merr errors.MultiError
+	if len(request.Name) == 0 {
+		merr.Append(errors.ArgumentMissing.With("name"))
+	}
+	if len(request.Email) == 0 {
+		merr.Append(errors.ArgumentMissing.With("email"))
+	} else if _, err := mail.ParseAddress(request.Email); err != nil {
+		merr.Append(errors.ArgumentInvalid.With("email", request.Email))
+	}
+	return merr.AsError()
+}
+
+// Redact redacts sensitive data
+//
+// implements logger.Redactable
+func (request UserRequest) Redact() interface{} {
+	redacted := request
+	if len(request.Name) > 0 {
+		redacted.Name = logger.RedactWithHash(request.Name)
+	}
+	if len(request.Email) > 0 {
+		redacted.Email = logger.RedactWithHash(request.Email)
+	}
+	if len(request.Password) > 0 {
+		redacted.Password = "REDACTED"
+	}
+	return redacted
+}
+
+// MarshalJSON marshals this into JSON
+//
+// implements json.Marshaler
+func (request UserRequest) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		Name       string     `json:"name"`
+		Email      string     `json:"email"`
+		Department string     `json:"department,omitempty"`
+		Title      string     `json:"title,omitempty"`
+		Password   string     `json:"password,omitempty"`
+		DivisionID string     `json:"divisionId,omitempty"`
+		Addresses  []*Contact `json:"addresses,omitempty"`
+	}{
+		Name:       request.Name,
+		Email:      request.Email,
+		Department: request.Department,
+		Title:      request.Title,
+		Password:   request.Password,
+		DivisionID: identifiableID(request.Division),
+		Addresses:  request.Addresses,
+	})
+	return data, errors.JSONMarshalError.Wrap(err)
+}
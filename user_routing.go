@@ -0,0 +1,103 @@
+package gcloudcx
+
+import (
+	"context"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
+
+// GetPresence gets the current Genesys Cloud presence of this User
+func (user User) GetPresence(context context.Context) (*UserPresence, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	presence := &UserPresence{}
+	correlationID, err := user.client.Get(user.logger.ToContext(context), NewURI("/users/%s/presences/PURECLOUD", user.ID), presence)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return presence, correlationID, nil
+}
+
+// SetPresence sets the Genesys Cloud presence of this User
+//
+// definition is typically a PresenceDefinition, the message is optional.
+func (user User) SetPresence(context context.Context, definition Identifiable, message string) (*UserPresence, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if definition == nil || definition.GetID() == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("presenceDefinition")
+	}
+	presence := &UserPresence{}
+	correlationID, err := user.client.Patch(
+		user.logger.ToContext(context),
+		NewURI("/users/%s/presences/PURECLOUD", user.ID),
+		struct {
+			Definition EntityRef `json:"presenceDefinition"`
+			Message    string    `json:"message,omitempty"`
+		}{
+			Definition: EntityRef{ID: definition.GetID()},
+			Message:    message,
+		},
+		presence,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return presence, correlationID, nil
+}
+
+// SetPresenceByName sets the Genesys Cloud presence of this User by its name
+//
+// The name is resolved with the PresenceCatalog of the Client, it can be a label like "Break" or a system presence like "Available".
+func (user User) SetPresenceByName(context context.Context, name string, message string) (*UserPresence, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	definition, correlationID, err := user.client.PresenceCatalog().Resolve(context, name)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return user.SetPresence(context, definition, message)
+}
+
+// GetRoutingStatus gets the routing status of this User
+func (user User) GetRoutingStatus(context context.Context) (*RoutingStatus, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	status := &RoutingStatus{}
+	correlationID, err := user.client.Get(user.logger.ToContext(context), NewURI("/users/%s/routingstatus", user.ID), status)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return status, correlationID, nil
+}
+
+// SetRoutingStatus sets the routing status of this User
+//
+// Only RoutingStatusIdle (on queue) and RoutingStatusOffQueue can be set.
+func (user User) SetRoutingStatus(context context.Context, status string) (*RoutingStatus, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if status != RoutingStatusIdle && status != RoutingStatusOffQueue {
+		return nil, "", errors.ArgumentInvalid.With("status", status, RoutingStatusIdle+", "+RoutingStatusOffQueue)
+	}
+	updated := &RoutingStatus{}
+	correlationID, err := user.client.Put(
+		user.logger.ToContext(context),
+		NewURI("/users/%s/routingstatus", user.ID),
+		struct {
+			UserID string `json:"userId"`
+			Status string `json:"status"`
+		}{UserID: user.ID.String(), Status: status},
+		updated,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return updated, correlationID, nil
+}
@@ -1,6 +1,12 @@
 package gcloudcx
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
 
 // UserRoutingLanguage describe a Routing Language for a User
 type UserRoutingLanguage struct {
@@ -25,3 +31,83 @@ func (userRoutingLanguage UserRoutingLanguage) GetID() uuid.UUID {
 func (userRoutingLanguage UserRoutingLanguage) GetURI() URI {
 	return userRoutingLanguage.SelfURI
 }
+
+// FetchRoutingLanguages fetches the routing languages of this User
+func (user User) FetchRoutingLanguages(context context.Context) ([]*UserRoutingLanguage, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	entities, correlationID, err := user.client.FetchEntities(user.logger.ToContext(context), NewURI("/users/%s/routinglanguages?pageSize=100", user.ID))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	languages := make([]*UserRoutingLanguage, 0, len(entities))
+	for _, entity := range entities {
+		language := &UserRoutingLanguage{}
+		if err := json.Unmarshal(entity, language); err != nil {
+			return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+		}
+		languages = append(languages, language)
+	}
+	return languages, correlationID, nil
+}
+
+// AssignRoutingLanguage assigns a routing language to this User with the given proficiency (0 to 5)
+func (user User) AssignRoutingLanguage(context context.Context, language Identifiable, proficiency float64) (*UserRoutingLanguage, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if err := validateRoutingProficiency(language, proficiency); err != nil {
+		return nil, "", err
+	}
+	assigned := &UserRoutingLanguage{}
+	correlationID, err := user.client.Post(
+		user.logger.ToContext(context),
+		NewURI("/users/%s/routinglanguages", user.ID),
+		struct {
+			ID          string  `json:"id"`
+			Proficiency float64 `json:"proficiency"`
+		}{ID: language.GetID().String(), Proficiency: proficiency},
+		assigned,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return assigned, correlationID, nil
+}
+
+// UpdateRoutingLanguage updates the proficiency (0 to 5) of a routing language of this User
+func (user User) UpdateRoutingLanguage(context context.Context, language Identifiable, proficiency float64) (*UserRoutingLanguage, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if err := validateRoutingProficiency(language, proficiency); err != nil {
+		return nil, "", err
+	}
+	updated := &UserRoutingLanguage{}
+	correlationID, err := user.client.Patch(
+		user.logger.ToContext(context),
+		NewURI("/users/%s/routinglanguages/%s", user.ID, language.GetID()),
+		struct {
+			ID          string  `json:"id"`
+			Proficiency float64 `json:"proficiency"`
+			State       string  `json:"state"`
+		}{ID: language.GetID().String(), Proficiency: proficiency, State: "active"},
+		updated,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return updated, correlationID, nil
+}
+
+// RemoveRoutingLanguage removes a routing language from this User
+func (user User) RemoveRoutingLanguage(context context.Context, language Identifiable) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	if language == nil || language.GetID() == uuid.Nil {
+		return "", errors.ArgumentMissing.With("language")
+	}
+	return user.client.Delete(user.logger.ToContext(context), NewURI("/users/%s/routinglanguages/%s", user.ID, language.GetID()), nil)
+}
@@ -1,6 +1,13 @@
 package gcloudcx
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/gildas/go-errors"
+	"github.com/google/uuid"
+)
 
 // UserRoutingSkill describe a Routing Skill for a User
 type UserRoutingSkill struct {
@@ -25,3 +32,195 @@ func (userRoutingSkill UserRoutingSkill) GetID() uuid.UUID {
 func (userRoutingSkill UserRoutingSkill) GetURI() URI {
 	return userRoutingSkill.SelfURI
 }
+
+// RoutingSkillChanges describes the changes to apply to the routing skills of a User
+type RoutingSkillChanges struct {
+	Upserts  []UserRoutingSkill // skills to add or whose proficiency changes
+	Removals []uuid.UUID        // skills to remove
+}
+
+// routingSkillsBulkSize is the maximum number of skills per bulk request
+const routingSkillsBulkSize = 50
+
+// userRoutingSkillPost is the payload to assign a skill to a User
+type userRoutingSkillPost struct {
+	ID          string  `json:"id"`
+	Proficiency float64 `json:"proficiency"`
+}
+
+// IsEmpty tells if there is nothing to change
+func (changes RoutingSkillChanges) IsEmpty() bool {
+	return len(changes.Upserts) == 0 && len(changes.Removals) == 0
+}
+
+// DiffRoutingSkills computes the changes to apply to the current skills of a User to get the desired skills
+//
+// desired maps the skill IDs to their proficiency. Skills that are not in desired are removed.
+func DiffRoutingSkills(current []*UserRoutingSkill, desired map[uuid.UUID]float64) RoutingSkillChanges {
+	changes := RoutingSkillChanges{}
+	existing := map[uuid.UUID]float64{}
+	for _, skill := range current {
+		if skill == nil {
+			continue
+		}
+		existing[skill.ID] = skill.Proficiency
+		if _, found := desired[skill.ID]; !found {
+			changes.Removals = append(changes.Removals, skill.ID)
+		}
+	}
+	for id, proficiency := range desired {
+		if current, found := existing[id]; !found || current != proficiency {
+			changes.Upserts = append(changes.Upserts, UserRoutingSkill{ID: id, Proficiency: proficiency})
+		}
+	}
+	sort.Slice(changes.Upserts, func(i, j int) bool { return changes.Upserts[i].ID.String() < changes.Upserts[j].ID.String() })
+	sort.Slice(changes.Removals, func(i, j int) bool { return changes.Removals[i].String() < changes.Removals[j].String() })
+	return changes
+}
+
+// FetchRoutingSkills fetches the routing skills of this User
+func (user User) FetchRoutingSkills(context context.Context) ([]*UserRoutingSkill, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	entities, correlationID, err := user.client.FetchEntities(user.logger.ToContext(context), NewURI("/users/%s/routingskills?pageSize=100", user.ID))
+	if err != nil {
+		return nil, correlationID, err
+	}
+	skills := make([]*UserRoutingSkill, 0, len(entities))
+	for _, entity := range entities {
+		skill := &UserRoutingSkill{}
+		if err := json.Unmarshal(entity, skill); err != nil {
+			return nil, correlationID, errors.JSONUnmarshalError.Wrap(err)
+		}
+		skills = append(skills, skill)
+	}
+	return skills, correlationID, nil
+}
+
+// AssignRoutingSkill assigns a routing skill to this User with the given proficiency (0 to 5)
+func (user User) AssignRoutingSkill(context context.Context, skill Identifiable, proficiency float64) (*UserRoutingSkill, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if err := validateRoutingProficiency(skill, proficiency); err != nil {
+		return nil, "", err
+	}
+	assigned := &UserRoutingSkill{}
+	correlationID, err := user.client.Post(
+		user.logger.ToContext(context),
+		NewURI("/users/%s/routingskills", user.ID),
+		userRoutingSkillPost{ID: skill.GetID().String(), Proficiency: proficiency},
+		assigned,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return assigned, correlationID, nil
+}
+
+// UpdateRoutingSkill updates the proficiency (0 to 5) of a routing skill of this User
+func (user User) UpdateRoutingSkill(context context.Context, skill Identifiable, proficiency float64) (*UserRoutingSkill, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	if err := validateRoutingProficiency(skill, proficiency); err != nil {
+		return nil, "", err
+	}
+	updated := &UserRoutingSkill{}
+	correlationID, err := user.client.Put(
+		user.logger.ToContext(context),
+		NewURI("/users/%s/routingskills/%s", user.ID, skill.GetID()),
+		struct {
+			ID          string  `json:"id"`
+			Proficiency float64 `json:"proficiency"`
+			State       string  `json:"state"`
+		}{ID: skill.GetID().String(), Proficiency: proficiency, State: "active"},
+		updated,
+	)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return updated, correlationID, nil
+}
+
+// RemoveRoutingSkill removes a routing skill from this User
+func (user User) RemoveRoutingSkill(context context.Context, skill Identifiable) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	if skill == nil || skill.GetID() == uuid.Nil {
+		return "", errors.ArgumentMissing.With("skill")
+	}
+	return user.client.Delete(user.logger.ToContext(context), NewURI("/users/%s/routingskills/%s", user.ID, skill.GetID()), nil)
+}
+
+// ApplyRoutingSkills changes the routing skills of this User so they match the desired skills
+//
+// desired maps the skill IDs to their proficiency. The current skills are fetched and only the differences are sent:
+// skills to add or update are sent with bulk PATCH requests, and skills to remove are deleted one by one.
+//
+// The applied changes are returned.
+func (user User) ApplyRoutingSkills(context context.Context, desired map[uuid.UUID]float64) (RoutingSkillChanges, string, error) {
+	for id, proficiency := range desired {
+		if err := validateRoutingProficiency(EntityRef{ID: id}, proficiency); err != nil {
+			return RoutingSkillChanges{}, "", err
+		}
+	}
+	current, correlationID, err := user.FetchRoutingSkills(context)
+	if err != nil {
+		return RoutingSkillChanges{}, correlationID, err
+	}
+	changes := DiffRoutingSkills(current, desired)
+	if changes.IsEmpty() {
+		user.logger.Debugf("Routing skills of user %s are already up to date", user.ID)
+		return changes, correlationID, nil
+	}
+	for start := 0; start < len(changes.Upserts); start += routingSkillsBulkSize {
+		end := min(start+routingSkillsBulkSize, len(changes.Upserts))
+		payload := make([]userRoutingSkillPost, 0, end-start)
+		for _, skill := range changes.Upserts[start:end] {
+			payload = append(payload, userRoutingSkillPost{ID: skill.ID.String(), Proficiency: skill.Proficiency})
+		}
+		if correlationID, err = user.client.Patch(user.logger.ToContext(context), NewURI("/users/%s/routingskills/bulk", user.ID), payload, nil); err != nil {
+			return changes, correlationID, err
+		}
+	}
+	for _, id := range changes.Removals {
+		if correlationID, err = user.RemoveRoutingSkill(context, EntityRef{ID: id}); err != nil {
+			return changes, correlationID, err
+		}
+	}
+	return changes, correlationID, nil
+}
+
+// ApplyUsersRoutingSkills changes the routing skills of several Users so they match their desired skills
+//
+// desired maps the user IDs to their desired skills, see User.ApplyRoutingSkills.
+func (client *Client) ApplyUsersRoutingSkills(context context.Context, desired map[uuid.UUID]map[uuid.UUID]float64) UserResults {
+	ids := make([]uuid.UUID, 0, len(desired))
+	for id := range desired {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+
+	results := make(UserResults, 0, len(ids))
+	for _, id := range ids {
+		user := User{ID: id}
+		user.Initialize(client, client.Logger)
+		_, correlationID, err := user.ApplyRoutingSkills(context, desired[id])
+		results = append(results, UserResult{ID: id, CorrelationID: correlationID, Error: err})
+	}
+	return results
+}
+
+// validateRoutingProficiency validates a skill or language and its proficiency
+func validateRoutingProficiency(identifiable Identifiable, proficiency float64) error {
+	if identifiable == nil || identifiable.GetID() == uuid.Nil {
+		return errors.ArgumentMissing.With("id")
+	}
+	if proficiency < 0 || proficiency > 5 {
+		return errors.ArgumentInvalid.With("proficiency", proficiency, "0 to 5")
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-core"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type UserRoutingSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestUserRoutingSuite(t *testing.T) {
+	suite.Run(t, new(UserRoutingSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *UserRoutingSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *UserRoutingSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *UserRoutingSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *UserRoutingSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *UserRoutingSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+
+func (suite *UserRoutingSuite) TestCanSetPresenceByName() {
+	userID := uuid.New()
+	availableID := uuid.New()
+	awayID := uuid.New()
+	breakID := uuid.New()
+	definitionsFetched := 0
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PATCH /api/v2/users/%s/presences/PURECLOUD", userID): map[string]any{"presenceDefinition": map[string]any{"id": breakID, "systemPresence": "Break"}, "message": "Coffee"},
+		fmt.Sprintf("GET /api/v2/users/%s/presences/PURECLOUD", userID):   map[string]any{"presenceDefinition": map[string]any{"id": availableID, "systemPresence": "Available"}},
+	})
+	defer server.Close()
+	server.Responses["GET /api/v2/presencedefinitions"] = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		definitionsFetched++
+		core.RespondWithJSON(w, http.StatusOK, map[string]any{
+			"entities": []map[string]any{
+				{"id": availableID, "systemPresence": "Available", "primary": true, "languageLabels": map[string]string{"en_US": "Available"}},
+				{"id": uuid.New(), "systemPresence": "Away", "deactivated": true, "languageLabels": map[string]string{"en_US": "Gone"}},
+				{"id": awayID, "systemPresence": "Away", "primary": true, "languageLabels": map[string]string{"en_US": "Away"}},
+				{"id": breakID, "systemPresence": "Break", "primary": true, "languageLabels": map[string]string{"en_US": "Break", "fr": "Pause"}},
+			},
+			"pageCount": 1,
+		})
+	})
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+
+	presence, _, err := user.SetPresenceByName(context.Background(), "pause", "Coffee")
+	suite.Require().NoErrorf(err, "Failed to set presence. %s", err)
+	suite.Assert().Equal(breakID, presence.Definition.ID)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"presenceDefinition": {"id": "%s"}, "message": "Coffee"}`, breakID), string(server.LastRequest().Body))
+
+	definition, _, err := client.PresenceCatalog().Resolve(context.Background(), "AVAILABLE")
+	suite.Require().NoErrorf(err, "Failed to resolve presence. %s", err)
+	suite.Assert().Equal(availableID, definition.ID)
+	suite.Assert().Equal(1, definitionsFetched, "Presence definitions should be cached")
+
+	_, _, err = client.PresenceCatalog().Resolve(context.Background(), "Gone")
+	suite.Assert().Error(err, "Deactivated presences should not be resolved")
+
+	client.PresenceCatalog().Invalidate()
+	_, _, err = client.PresenceCatalog().Resolve(context.Background(), "Away")
+	suite.Require().NoErrorf(err, "Failed to resolve presence. %s", err)
+	suite.Assert().Equal(2, definitionsFetched, "Presence definitions should be fetched again after invalidation")
+
+	presence, _, err = user.GetPresence(context.Background())
+	suite.Require().NoErrorf(err, "Failed to get presence. %s", err)
+	suite.Assert().Equal("Available", presence.String())
+}
+
+func (suite *UserRoutingSuite) TestCanSetRoutingStatus() {
+	userID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PUT /api/v2/users/%s/routingstatus", userID): map[string]any{"userId": userID, "status": "IDLE"},
+		fmt.Sprintf("GET /api/v2/users/%s/routingstatus", userID): map[string]any{"userId": userID, "status": "INTERACTING"},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+
+	status, _, err := user.SetRoutingStatus(context.Background(), gcloudcx.RoutingStatusIdle)
+	suite.Require().NoErrorf(err, "Failed to set routing status. %s", err)
+	suite.Assert().Equal(gcloudcx.RoutingStatusIdle, status.Status)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"userId": "%s", "status": "IDLE"}`, userID), string(server.LastRequest().Body))
+
+	status, _, err = user.GetRoutingStatus(context.Background())
+	suite.Require().NoErrorf(err, "Failed to get routing status. %s", err)
+	suite.Assert().Equal(gcloudcx.RoutingStatusInteracting, status.Status)
+
+	_, _, err = user.SetRoutingStatus(context.Background(), gcloudcx.RoutingStatusInteracting)
+	suite.Assert().Error(err, "Setting the routing status to INTERACTING should fail")
+}
+
+func (suite *UserRoutingSuite) TestCanFetchSkillsAndLanguagesByName() {
+	frenchID := uuid.New()
+	billingID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET /api/v2/routing/skills/": map[string]any{
+			"entities":  []map[string]any{{"id": uuid.New(), "name": "Sales"}, {"id": billingID, "name": "Billing"}},
+			"pageCount": 1,
+		},
+		"GET /api/v2/routing/languages/": map[string]any{
+			"entities":  []map[string]any{{"id": frenchID, "name": "French"}},
+			"pageCount": 1,
+		},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	skill, _, err := gcloudcx.FetchBy(context.Background(), client, func(skill gcloudcx.RoutingSkill) bool { return skill.Name == "Billing" })
+	suite.Require().NoErrorf(err, "Failed to fetch skill. %s", err)
+	suite.Assert().Equal(billingID, skill.GetID())
+
+	language, _, err := gcloudcx.FetchBy(context.Background(), client, func(language gcloudcx.RoutingLanguage) bool { return language.Name == "French" })
+	suite.Require().NoErrorf(err, "Failed to fetch language. %s", err)
+	suite.Assert().Equal(frenchID, language.GetID())
+
+	_, _, err = gcloudcx.FetchBy(context.Background(), client, func(skill gcloudcx.RoutingSkill) bool { return skill.Name == "Unknown" })
+	suite.Assert().Error(err, "Fetching an unknown skill should fail")
+}
+
+func (suite *UserRoutingSuite) TestCanManageUserRoutingSkillsAndLanguages() {
+	userID := uuid.New()
+	skillID := uuid.New()
+	languageID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("POST /api/v2/users/%s/routingskills", userID):                     map[string]any{"id": skillID, "proficiency": 3},
+		fmt.Sprintf("PUT /api/v2/users/%s/routingskills/%s", userID, skillID):          map[string]any{"id": skillID, "proficiency": 4},
+		fmt.Sprintf("DELETE /api/v2/users/%s/routingskills/%s", userID, skillID):       struct{}{},
+		fmt.Sprintf("POST /api/v2/users/%s/routinglanguages", userID):                  map[string]any{"id": languageID, "proficiency": 5},
+		fmt.Sprintf("PATCH /api/v2/users/%s/routinglanguages/%s", userID, languageID):  map[string]any{"id": languageID, "proficiency": 2},
+		fmt.Sprintf("DELETE /api/v2/users/%s/routinglanguages/%s", userID, languageID): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	user := gcloudcx.New[gcloudcx.User](context.Background(), client, userID, suite.Logger)
+	skill := gcloudcx.RoutingSkill{ID: skillID}
+	language := gcloudcx.RoutingLanguage{ID: languageID}
+
+	assigned, _, err := user.AssignRoutingSkill(context.Background(), skill, 3)
+	suite.Require().NoErrorf(err, "Failed to assign skill. %s", err)
+	suite.Assert().Equal(3.0, assigned.Proficiency)
+	suite.Assert().JSONEq(fmt.Sprintf(`{"id": "%s", "proficiency": 3}`, skillID), string(server.LastRequest().Body))
+
+	updated, _, err := user.UpdateRoutingSkill(context.Background(), skill, 4)
+	suite.Require().NoErrorf(err, "Failed to update skill. %s", err)
+	suite.Assert().Equal(4.0, updated.Proficiency)
+
+	_, err = user.RemoveRoutingSkill(context.Background(), skill)
+	suite.Require().NoErrorf(err, "Failed to remove skill. %s", err)
+
+	_, _, err = user.AssignRoutingSkill(context.Background(), skill, 6)
+	suite.Assert().Error(err, "A proficiency above 5 should fail")
+
+	_, _, err = user.AssignRoutingLanguage(context.Background(), language, 5)
+	suite.Require().NoErrorf(err, "Failed to assign language. %s", err)
+	languageUpdated, _, err := user.UpdateRoutingLanguage(context.Background(), language, 2)
+	suite.Require().NoErrorf(err, "Failed to update language. %s", err)
+	suite.Assert().Equal(2.0, languageUpdated.Proficiency)
+	_, err = user.RemoveRoutingLanguage(context.Background(), language)
+	suite.Require().NoErrorf(err, "Failed to remove language. %s", err)
+}
+
+func (suite *UserRoutingSuite) TestCanApplyDesiredRoutingSkills() {
+	userID := uuid.New()
+	kept, changed, removed, added := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("GET /api/v2/users/%s/routingskills", userID): map[string]any{
+			"entities": []map[string]any{
+				{"id": kept, "proficiency": 3},
+				{"id": changed, "proficiency": 1},
+				{"id": removed, "proficiency": 5},
+			},
+			"pageCount": 1,
+		},
+		fmt.Sprintf("PATCH /api/v2/users/%s/routingskills/bulk", userID):         []any{},
+		fmt.Sprintf("DELETE /api/v2/users/%s/routingskills/%s", userID, removed): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	desired := map[uuid.UUID]float64{kept: 3, changed: 4, added: 2}
+	results := client.ApplyUsersRoutingSkills(context.Background(), map[uuid.UUID]map[uuid.UUID]float64{userID: desired})
+	suite.Require().NoError(results.AsError())
+	suite.Require().Len(server.Requests, 3)
+	suite.Assert().Equal("PATCH", server.Requests[1].Method)
+	var patched []map[string]any
+	suite.Require().NoError(json.Unmarshal(server.Requests[1].Body, &patched))
+	suite.Assert().ElementsMatch([]map[string]any{
+		{"id": changed.String(), "proficiency": 4.0},
+		{"id": added.String(), "proficiency": 2.0},
+	}, patched, "Only the changed and added skills should be sent")
+	suite.Assert().Equal("DELETE", server.Requests[2].Method)
+
+	changes := gcloudcx.DiffRoutingSkills([]*gcloudcx.UserRoutingSkill{{ID: kept, Proficiency: 3}}, map[uuid.UUID]float64{kept: 3})
+	suite.Assert().True(changes.IsEmpty(), "Identical skills should not produce changes")
+}
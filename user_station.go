@@ -1,8 +1,10 @@
 package gcloudcx
 
 import (
+	"context"
 	"time"
 
+	"github.com/gildas/go-errors"
 	"github.com/google/uuid"
 )
 
@@ -40,3 +42,83 @@ func (station UserStation) GetID() uuid.UUID {
 func (station UserStation) GetURI() URI {
 	return station.SelfURI
 }
+
+// FetchStations fetches the stations of this User
+func (user User) FetchStations(context context.Context) (*UserStations, string, error) {
+	if err := user.checkInitialized(); err != nil {
+		return nil, "", err
+	}
+	stations := &UserStations{}
+	correlationID, err := user.client.Get(user.logger.ToContext(context), NewURI("/users/%s/station", user.ID), stations)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	return stations, correlationID, nil
+}
+
+// AssociateStation associates a station to this User, it becomes its effective station
+func (user User) AssociateStation(context context.Context, station Identifiable) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	if station == nil || station.GetID() == uuid.Nil {
+		return "", errors.ArgumentMissing.With("station")
+	}
+	return user.client.Put(user.logger.ToContext(context), NewURI("/users/%s/station/associatedstation/%s", user.ID, station.GetID()), nil, nil)
+}
+
+// DisassociateStation disassociates the associated station of this User, its default station becomes its effective station
+func (user User) DisassociateStation(context context.Context) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	return user.client.Delete(user.logger.ToContext(context), NewURI("/users/%s/station/associatedstation", user.ID), nil)
+}
+
+// SetDefaultStation sets the default station of this User
+func (user User) SetDefaultStation(context context.Context, station Identifiable) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	if station == nil || station.GetID() == uuid.Nil {
+		return "", errors.ArgumentMissing.With("station")
+	}
+	return user.client.Put(user.logger.ToContext(context), NewURI("/users/%s/station/defaultstation/%s", user.ID, station.GetID()), nil, nil)
+}
+
+// ClearDefaultStation removes the default station of this User
+func (user User) ClearDefaultStation(context context.Context) (correlationID string, err error) {
+	if err = user.checkInitialized(); err != nil {
+		return
+	}
+	return user.client.Delete(user.logger.ToContext(context), NewURI("/users/%s/station/defaultstation", user.ID), nil)
+}
+
+// EnsureStation makes sure this User is logged into the given station before placing calls
+//
+// If the effective station of the User is already the given station, nothing is sent.
+// Otherwise the station is associated and the stations of the User are fetched again to verify the association.
+func (user User) EnsureStation(context context.Context, station Identifiable) (*UserStations, string, error) {
+	if station == nil || station.GetID() == uuid.Nil {
+		return nil, "", errors.ArgumentMissing.With("station")
+	}
+	stations, correlationID, err := user.FetchStations(context)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	if stations.EffectiveStation != nil && stations.EffectiveStation.ID == station.GetID() {
+		user.logger.Debugf("User %s is already logged into station %s", user.ID, station.GetID())
+		return stations, correlationID, nil
+	}
+	user.logger.Infof("Associating station %s to user %s", station.GetID(), user.ID)
+	if correlationID, err = user.AssociateStation(context, station); err != nil {
+		return nil, correlationID, err
+	}
+	if stations, correlationID, err = user.FetchStations(context); err != nil {
+		return nil, correlationID, err
+	}
+	if stations.EffectiveStation == nil || stations.EffectiveStation.ID != station.GetID() {
+		return stations, correlationID, errors.Errorf("User %s is not logged into station %s", user.ID, station.GetID())
+	}
+	return stations, correlationID, nil
+}
@@ -1,6 +1,7 @@
 package gcloudcx
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -18,6 +19,35 @@ type Wrapup struct {
 	Provisional bool          `json:"provisional"`
 }
 
+// SubmitWrapup submits the wrap-up of a participant after checking the After Call Work settings of its Queue
+//
+// acwStart is when the participant entered After Call Work (usually when it disconnected).
+//
+// If the settings force the wrap-up on timeout and the timeout is over, Genesys Cloud has already wrapped up
+// the participant and errors.Timeout is returned. If the wrap-up is mandatory, the wrapup must have a code.
+func SubmitWrapup(context context.Context, target WrapUpper, participant Identifiable, wrapup *Wrapup, settings ACWSettings, acwStart time.Time) (correlationID string, err error) {
+	if target == nil {
+		return "", errors.ArgumentMissing.With("target")
+	}
+	if participant == nil {
+		return "", errors.ArgumentMissing.With("participant")
+	}
+	if wrapup == nil {
+		return "", errors.ArgumentMissing.With("wrapup")
+	}
+	if settings.IsMandatory() && len(wrapup.Code) == 0 {
+		return "", errors.ArgumentMissing.With("wrapup.code")
+	}
+	now := time.Now()
+	if settings.WrapupPrompt == WrapupPromptMandatoryForcedTimeout && settings.IsExpired(acwStart, now) {
+		return "", errors.Timeout.With("wrapup")
+	}
+	if wrapup.Duration == 0 && !acwStart.IsZero() && acwStart.Before(now) {
+		wrapup.Duration = now.Sub(acwStart)
+	}
+	return target.Wrapup(context, participant, wrapup)
+}
+
 // MarshalJSON marshals this into JSON
 func (wrapup Wrapup) MarshalJSON() ([]byte, error) {
 	type surrogate Wrapup
@@ -0,0 +1,144 @@
+package gcloudcx
+
+import (
+	"context"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+)
+
+// WrapupCode describes a Wrap-up Code that agents select after a conversation
+//
+// See: https://developer.genesys.cloud/routing/routing/#get-api-v2-routing-wrapupcodes
+type WrapupCode struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Division    *DomainEntityRef `json:"division,omitempty"`
+	DateCreated time.Time        `json:"dateCreated,omitempty"`
+	CreatedBy   string           `json:"createdBy,omitempty"`
+	SelfURI     URI              `json:"selfUri,omitempty"`
+	client      *Client          `json:"-"`
+	logger      *logger.Logger   `json:"-"`
+}
+
+// Initialize initializes the object
+//
+// accepted parameters: *gcloudcx.Client, *logger.Logger
+//
+// implements Initializable
+func (code *WrapupCode) Initialize(parameters ...interface{}) {
+	for _, raw := range parameters {
+		switch parameter := raw.(type) {
+		case uuid.UUID:
+			code.ID = parameter
+		case *Client:
+			code.client = parameter
+		case *logger.Logger:
+			code.logger = parameter.Child("wrapupcode", "wrapupcode", "id", code.ID)
+		}
+	}
+	if code.logger == nil {
+		code.logger = logger.Create("gcloudcx", &logger.NilStream{})
+	}
+}
+
+// GetID gets the identifier of this
+//
+// implements Identifiable
+func (code WrapupCode) GetID() uuid.UUID {
+	return code.ID
+}
+
+// GetURI gets the URI of this
+//
+// implements Addressable
+func (code WrapupCode) GetURI(ids ...uuid.UUID) URI {
+	if len(ids) > 0 {
+		return NewURI("/api/v2/routing/wrapupcodes/%s", ids[0])
+	}
+	if code.ID != uuid.Nil {
+		return NewURI("/api/v2/routing/wrapupcodes/%s", code.ID)
+	}
+	return URI("/api/v2/routing/wrapupcodes/")
+}
+
+// GetName gets the name of this
+//
+// implements Named
+func (code WrapupCode) GetName() string {
+	return code.Name
+}
+
+// String gets a string version
+//
+// implements the fmt.Stringer interface
+func (code WrapupCode) String() string {
+	if len(code.Name) > 0 {
+		return code.Name
+	}
+	return code.ID.String()
+}
+
+// Create creates a new WrapupCode
+//
+// The Genesys Cloud correlation ID is return as the second return value.
+//
+// See: https://developer.genesys.cloud/routing/routing/#post-api-v2-routing-wrapupcodes
+func (code WrapupCode) Create(context context.Context, client *Client) (*WrapupCode, string, error) {
+	if len(code.Name) == 0 {
+		return nil, "", errors.ArgumentMissing.With("name")
+	}
+	created := WrapupCode{}
+	correlationID, err := client.Post(context, NewURI("/routing/wrapupcodes"), code, &created)
+	if err != nil {
+		return nil, correlationID, err
+	}
+	created.Initialize(client, client.Logger)
+	return &created, correlationID, nil
+}
+
+// Update updates this WrapupCode with its current name, description, and division
+func (code *WrapupCode) Update(context context.Context) (correlationID string, err error) {
+	if err = checkInitialized(code.client, "Wrapup Code", code.ID); err != nil {
+		return
+	}
+	if len(code.Name) == 0 {
+		return "", errors.ArgumentMissing.With("name")
+	}
+	updated := WrapupCode{}
+	if correlationID, err = code.client.Put(code.logger.ToContext(context), code.GetURI(), code, &updated); err != nil {
+		return
+	}
+	code.Name = updated.Name
+	code.Description = updated.Description
+	code.Division = updated.Division
+	return
+}
+
+// Delete deletes this WrapupCode
+func (code WrapupCode) Delete(context context.Context) (correlationID string, err error) {
+	if err = checkInitialized(code.client, "Wrapup Code", code.ID); err != nil {
+		return
+	}
+	return code.client.Delete(code.logger.ToContext(context), code.GetURI(), nil)
+}
+
+// NewWrapup creates a Wrapup with this code
+//
+// The duration of the wrap-up is computed from acwStart, the time the After Call Work started.
+func (code WrapupCode) NewWrapup(notes string, acwStart time.Time) *Wrapup {
+	now := time.Now().UTC()
+	wrapup := &Wrapup{
+		Name:    code.Name,
+		Code:    code.ID.String(),
+		Notes:   notes,
+		EndTime: now,
+	}
+	if !acwStart.IsZero() && acwStart.Before(now) {
+		wrapup.Duration = now.Sub(acwStart)
+	}
+	return wrapup
+}
@@ -0,0 +1,193 @@
+package gcloudcx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gildas/go-errors"
+	"github.com/gildas/go-logger"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/gildas/go-gcloudcx"
+)
+
+type WrapupCodeSuite struct {
+	suite.Suite
+	Name   string
+	Logger *logger.Logger
+	Start  time.Time
+}
+
+func TestWrapupCodeSuite(t *testing.T) {
+	suite.Run(t, new(WrapupCodeSuite))
+}
+
+// *****************************************************************************
+// #region: Suite Tools {{{
+func (suite *WrapupCodeSuite) SetupSuite() {
+	_ = godotenv.Load()
+	suite.Name = strings.TrimSuffix(reflect.TypeOf(suite).Elem().Name(), "Suite")
+	suite.Logger = logger.Create("test",
+		&logger.FileStream{
+			Path:         fmt.Sprintf("./log/test-%s.log", strings.ToLower(suite.Name)),
+			Unbuffered:   true,
+			SourceInfo:   true,
+			FilterLevels: logger.NewLevelSet(logger.TRACE),
+		},
+	).Child("test", "test")
+	suite.Logger.Infof("Suite Start: %s %s", suite.Name, strings.Repeat("=", 80-14-len(suite.Name)))
+}
+
+func (suite *WrapupCodeSuite) TearDownSuite() {
+	if suite.T().Failed() {
+		suite.Logger.Warnf("At least one test failed, we are not cleaning")
+		suite.T().Log("At least one test failed, we are not cleaning")
+	} else {
+		suite.Logger.Infof("All tests succeeded, we are cleaning")
+	}
+	suite.Logger.Infof("Suite End: %s %s", suite.Name, strings.Repeat("=", 80-12-len(suite.Name)))
+	suite.Logger.Close()
+}
+
+func (suite *WrapupCodeSuite) BeforeTest(suiteName, testName string) {
+	suite.Logger.Infof("Test Start: %s %s", testName, strings.Repeat("-", 80-13-len(testName)))
+	suite.Start = time.Now()
+}
+
+func (suite *WrapupCodeSuite) AfterTest(suiteName, testName string) {
+	duration := time.Since(suite.Start)
+	if suite.T().Failed() {
+		suite.Logger.Errorf("Test %s failed", testName)
+	}
+	suite.Logger.Record("duration", duration.String()).Infof("Test End: %s %s", testName, strings.Repeat("-", 80-11-len(testName)))
+}
+
+func (suite *WrapupCodeSuite) LoadTestData(filename string) []byte {
+	data, err := os.ReadFile(filepath.Join(".", "testdata", filename))
+	suite.Require().NoErrorf(err, "Failed to Load Data. %s", err)
+	return data
+}
+
+// #endregion: Suite Tools }}}
+func (suite *WrapupCodeSuite) TestCanFetchWrapupCodes() {
+	resolvedID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"GET /api/v2/routing/wrapupcodes/": map[string]any{
+			"entities": []map[string]any{
+				{"id": uuid.New(), "name": "Sale"},
+				{"id": resolvedID, "name": "Resolved"},
+			},
+			"pageCount": 1,
+		},
+		"GET /api/v2/routing/wrapupcodes/" + resolvedID.String(): map[string]any{"id": resolvedID, "name": "Resolved"},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	codes, _, err := gcloudcx.FetchAll[gcloudcx.WrapupCode](context.Background(), client)
+	suite.Require().NoErrorf(err, "Failed to fetch wrapup codes. %s", err)
+	suite.Assert().Len(codes, 2)
+
+	code, _, err := gcloudcx.FetchBy(context.Background(), client, func(code gcloudcx.WrapupCode) bool { return code.Name == "Resolved" })
+	suite.Require().NoErrorf(err, "Failed to fetch wrapup code by name. %s", err)
+	suite.Assert().Equal(resolvedID, code.GetID())
+
+	code, _, err = gcloudcx.Fetch[gcloudcx.WrapupCode](context.Background(), client, resolvedID)
+	suite.Require().NoErrorf(err, "Failed to fetch wrapup code. %s", err)
+	suite.Assert().Equal("Resolved", code.String())
+}
+
+func (suite *WrapupCodeSuite) TestCanManageWrapupCodes() {
+	codeID := uuid.New()
+	queueID := uuid.New()
+	server := CreateRecordingTestServer(map[string]any{
+		"POST /api/v2/routing/wrapupcodes":                                              map[string]any{"id": codeID, "name": "Resolved"},
+		"PUT /api/v2/routing/wrapupcodes/" + codeID.String():                            map[string]any{"id": codeID, "name": "Resolved", "description": "Issue resolved"},
+		"DELETE /api/v2/routing/wrapupcodes/" + codeID.String():                         struct{}{},
+		fmt.Sprintf("POST /api/v2/routing/queues/%s/wrapupcodes", queueID):              []any{},
+		fmt.Sprintf("DELETE /api/v2/routing/queues/%s/wrapupcodes/%s", queueID, codeID): struct{}{},
+		fmt.Sprintf("GET /api/v2/routing/queues/%s/wrapupcodes", queueID): map[string]any{
+			"entities":  []map[string]any{{"id": codeID, "name": "Resolved"}},
+			"pageCount": 1,
+		},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+
+	code, _, err := gcloudcx.WrapupCode{Name: "Resolved"}.Create(context.Background(), client)
+	suite.Require().NoErrorf(err, "Failed to create wrapup code. %s", err)
+	suite.Assert().Equal(codeID, code.GetID())
+
+	code.Description = "Issue resolved"
+	_, err = code.Update(context.Background())
+	suite.Require().NoErrorf(err, "Failed to update wrapup code. %s", err)
+	suite.Assert().Equal("Issue resolved", code.Description)
+
+	queue := gcloudcx.New[gcloudcx.Queue](context.Background(), client, suite.Logger)
+	queue.ID = queueID
+	_, err = queue.AddWrapupCodes(context.Background(), code)
+	suite.Require().NoErrorf(err, "Failed to assign wrapup code. %s", err)
+	suite.Assert().JSONEq(fmt.Sprintf(`[{"id": "%s"}]`, codeID), string(server.LastRequest().Body))
+
+	codes, _, err := queue.FetchWrapupCodes(context.Background())
+	suite.Require().NoErrorf(err, "Failed to fetch queue wrapup codes. %s", err)
+	suite.Require().Len(codes, 1)
+	suite.Assert().Equal(codeID, codes[0].GetID())
+
+	_, err = queue.RemoveWrapupCode(context.Background(), code)
+	suite.Require().NoErrorf(err, "Failed to remove wrapup code. %s", err)
+
+	_, err = code.Delete(context.Background())
+	suite.Require().NoErrorf(err, "Failed to delete wrapup code. %s", err)
+	suite.Assert().Equal(http.MethodDelete, server.LastRequest().Method)
+}
+
+func (suite *WrapupCodeSuite) TestCanSubmitWrapup() {
+	conversationID := uuid.New()
+	participant := gcloudcx.Participant{ID: uuid.New()}
+	server := CreateRecordingTestServer(map[string]any{
+		fmt.Sprintf("PATCH /api/v2/conversations/callbacks/%s/participants/%s", conversationID, participant.ID): struct{}{},
+	})
+	defer server.Close()
+	client := CreateTestClient(server.URL, suite.Logger)
+	callback := gcloudcx.New[gcloudcx.ConversationCallback](context.Background(), client, conversationID, suite.Logger)
+	code := gcloudcx.WrapupCode{ID: uuid.New(), Name: "Resolved"}
+	settings := gcloudcx.ACWSettings{WrapupPrompt: gcloudcx.WrapupPromptMandatoryForcedTimeout, Timeout: 30 * time.Second}
+
+	acwStart := time.Now().Add(-10 * time.Second)
+	suite.Assert().True(settings.IsMandatory())
+	suite.Assert().Equal(acwStart.Add(30*time.Second), settings.Deadline(acwStart))
+	suite.Assert().True(gcloudcx.ACWSettings{WrapupPrompt: gcloudcx.WrapupPromptOptional, Timeout: time.Minute}.Deadline(acwStart).IsZero())
+
+	_, err := gcloudcx.SubmitWrapup(context.Background(), callback, participant, code.NewWrapup("All good", acwStart), settings, acwStart)
+	suite.Require().NoErrorf(err, "Failed to submit wrapup. %s", err)
+	var payload struct {
+		Wrapup struct {
+			Code            string `json:"code"`
+			Notes           string `json:"notes"`
+			DurationSeconds int64  `json:"durationSeconds"`
+		} `json:"wrapup"`
+	}
+	suite.Require().NoError(json.Unmarshal(server.LastRequest().Body, &payload))
+	suite.Assert().Equal(code.ID.String(), payload.Wrapup.Code)
+	suite.Assert().Equal("All good", payload.Wrapup.Notes)
+	suite.Assert().GreaterOrEqual(payload.Wrapup.DurationSeconds, int64(10))
+
+	_, err = gcloudcx.SubmitWrapup(context.Background(), callback, participant, &gcloudcx.Wrapup{Notes: "No code"}, settings, acwStart)
+	suite.Assert().Error(err, "Submitting a mandatory wrapup without a code should fail")
+
+	expired := time.Now().Add(-time.Minute)
+	_, err = gcloudcx.SubmitWrapup(context.Background(), callback, participant, code.NewWrapup("Too late", expired), settings, expired)
+	suite.Require().Error(err, "Submitting a wrapup after a forced timeout should fail")
+	suite.Assert().ErrorIs(err, errors.Timeout)
+}